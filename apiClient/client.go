@@ -15,7 +15,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/go-yaml/yaml"
 	"github.com/machinebox/graphql"
 	"github.com/mitchellh/go-homedir"
 	errorsHandler "github.com/turbot/steampipe-plugin-guardrails/errors"
@@ -27,6 +26,9 @@ type Client struct {
 	AccessKey string
 	SecretKey string
 	Graphql   *graphql.Client
+	// set when the keys come from a credential_process, so they can be
+	// refreshed when they expire
+	processConfig *ClientConfig
 }
 
 func CreateClient(config ClientConfig, opts ...graphql.ClientOption) (*Client, error) {
@@ -37,11 +39,15 @@ func CreateClient(config ClientConfig, opts ...graphql.ClientOption) (*Client, e
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials, error: %s", err.Error())
 	}
-	return &Client{
+	client := &Client{
 		AccessKey: credentials.AccessKey,
 		SecretKey: credentials.SecretKey,
 		Graphql:   graphql.NewClient(credentials.Workspace, opts...),
-	}, nil
+	}
+	if usesCredentialProcess(config) {
+		client.processConfig = &config
+	}
+	return client, nil
 }
 
 func GetCredentials(config ClientConfig) (ClientCredentials, error) {
@@ -63,37 +69,95 @@ func GetCredentials(config ClientConfig) (ClientCredentials, error) {
 /*
 precedence of credentials:
 - Credentials set in config
+- access_key_file and secret_key_file set in config
+- credential_process set in config
 - profile set in config
 - ENV vars {TURBOT_ACCESS_KEY, TURBOT_SECRET_KEY, TURBOT_WORKSPACE}
 - TURBOT_PROFILE env var
+
+Credentials from the files and the credential process are combined with any
+set in config, so e.g. the workspace can be set in config with the keys read
+from files.
 */
 func getCredentialsByPrecedence(config ClientConfig) (ClientCredentials, error) {
+	resolved, err := resolveCredentials(config)
+	return resolved.Credentials, err
+}
+
+// Where the credentials were found, see getCredentialsByPrecedence
+const (
+	CredentialSourceConfig            = "config"
+	CredentialSourceKeyFile           = "key_file"
+	CredentialSourceCredentialProcess = "credential_process"
+	CredentialSourceEnv               = "env"
+	CredentialSourceProfile           = "profile"
+)
+
+type ResolvedCredentials struct {
+	Credentials ClientCredentials
+	// Profile the credentials were loaded from, nil unless Source is CredentialSourceProfile
+	Profile *CredentialsProfile
+	Source  string
+}
+
+// ResolveCredentials returns the credentials for the config along with where
+// they were found
+func ResolveCredentials(config ClientConfig) (ResolvedCredentials, error) {
+	return resolveCredentials(config)
+}
+
+// GetCredentialsProfile returns the profile the credentials are loaded from,
+// or nil if the credentials don't come from the credentials file
+func GetCredentialsProfile(config ClientConfig) (*CredentialsProfile, error) {
+	resolved, err := resolveCredentials(config)
+	return resolved.Profile, err
+}
+
+// resolveCredentials applies the precedence documented on getCredentialsByPrecedence
+func resolveCredentials(config ClientConfig) (ResolvedCredentials, error) {
+	var err error
 	credentials := config.Credentials
-	if !CredentialsSet(credentials) {
-		var err error
-		credentialsPath, err := getCredentialsPath(config)
+	if CredentialsSet(credentials) {
+		return ResolvedCredentials{Credentials: credentials, Source: CredentialSourceConfig}, nil
+	}
+	if len(config.AccessKeyFile) != 0 || len(config.SecretKeyFile) != 0 {
+		credentials, err = getFileCredentials(config)
 		if err != nil {
-			return ClientCredentials{}, err
+			return ResolvedCredentials{}, err
 		}
-		if len(config.Profile) != 0 {
-			credentials, err = getProfileCredentials(config)
-			if err != nil {
-				return ClientCredentials{}, err
-			}
-		} else {
-			var credentialsOk bool
-			credentials, credentialsOk = getCredentialsFromEnv()
-			// if credentials were not passed in, get from the credentials file
-			if !credentialsOk {
-				config.Profile = os.Getenv("TURBOT_PROFILE")
-				credentials, err = loadProfile(credentialsPath, config.Profile)
-				if err != nil {
-					return ClientCredentials{}, err
-				}
-			}
+		if CredentialsSet(credentials) {
+			return ResolvedCredentials{Credentials: credentials, Source: CredentialSourceKeyFile}, nil
 		}
+		config.Credentials = credentials
 	}
-	return credentials, nil
+	if len(config.CredentialProcess) != 0 {
+		credentials, err = getProcessCredentials(config)
+		if err != nil {
+			return ResolvedCredentials{}, err
+		}
+		if CredentialsSet(credentials) {
+			return ResolvedCredentials{Credentials: credentials, Source: CredentialSourceCredentialProcess}, nil
+		}
+	}
+
+	credentialsPath, err := getCredentialsPath(config)
+	if err != nil {
+		return ResolvedCredentials{}, err
+	}
+	if len(config.Profile) == 0 {
+		var credentialsOk bool
+		credentials, credentialsOk = getCredentialsFromEnv()
+		if credentialsOk {
+			return ResolvedCredentials{Credentials: credentials, Source: CredentialSourceEnv}, nil
+		}
+		// if credentials were not passed in, get from the credentials file
+		config.Profile = os.Getenv("TURBOT_PROFILE")
+	}
+	profile, err := loadProfile(credentialsPath, config.Profile)
+	if err != nil {
+		return ResolvedCredentials{}, err
+	}
+	return ResolvedCredentials{Credentials: profile.credentials(), Profile: &profile, Source: CredentialSourceProfile}, nil
 }
 
 func getCredentialsFromEnv() (ClientCredentials, bool) {
@@ -105,18 +169,6 @@ func getCredentialsFromEnv() (ClientCredentials, bool) {
 	return credentials, CredentialsSet(credentials)
 }
 
-func getProfileCredentials(config ClientConfig) (ClientCredentials, error) {
-	credentialsPath, err := getCredentialsPath(config)
-	if err != nil {
-		return ClientCredentials{}, err
-	}
-	credentials, err := loadProfile(credentialsPath, config.Profile)
-	if err != nil {
-		return ClientCredentials{}, err
-	}
-	return credentials, nil
-}
-
 func getCredentialsPath(config ClientConfig) (string, error) {
 	var err error
 	credentialsPath := config.CredentialsPath
@@ -199,29 +251,6 @@ func userHomeDir() string {
 	return os.Getenv("HOME")
 }
 
-func loadProfile(credentialsPath, profile string) (ClientCredentials, error) {
-	// if no profile specified, use default
-	if len(profile) == 0 {
-		profile = "default"
-	}
-	yamlFile, err := os.ReadFile(credentialsPath)
-	if err != nil {
-		return ClientCredentials{}, err
-	}
-
-	var credentialsMap = map[string]ClientCredentials{}
-	err = yaml.Unmarshal(yamlFile, &credentialsMap)
-	if err != nil {
-		log.Fatalf("Unmarshal: %v", err)
-	}
-	credentials := credentialsMap[profile]
-	if !CredentialsSet(credentials) {
-		return ClientCredentials{}, fmt.Errorf("failed to load all credentials for profile %s from credentials file %s", profile, credentialsPath)
-	}
-
-	return credentials, nil
-}
-
 func basicAuthHeader(username, password string) string {
 	auth := username + ":" + password
 	return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
@@ -279,9 +308,19 @@ func (client *Client) DoRequest(query string, vars map[string]interface{}, respo
 		req.Var(k, v)
 	}
 
+	accessKey, secretKey := client.AccessKey, client.SecretKey
+	if client.processConfig != nil {
+		// cached until the credentials expire, then the process is run again
+		credentials, err := getProcessCredentials(*client.processConfig)
+		if err != nil {
+			return err
+		}
+		accessKey, secretKey = credentials.AccessKey, credentials.SecretKey
+	}
+
 	// set header fields
 	req.Header.Set("Cache-Control", "no-cache")
-	req.Header.Set("Authorization", basicAuthHeader(client.AccessKey, client.SecretKey))
+	req.Header.Set("Authorization", basicAuthHeader(accessKey, secretKey))
 
 	// define a Context for the request
 	ctx := context.Background()
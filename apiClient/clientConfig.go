@@ -4,6 +4,11 @@ type ClientConfig struct {
 	Credentials     ClientCredentials
 	CredentialsPath string
 	Profile         string
+	// Command to run to get credentials, which prints credentials JSON to stdout
+	CredentialProcess string
+	// Files containing the access key and secret key, e.g. mounted secrets
+	AccessKeyFile string
+	SecretKeyFile string
 }
 
 type ClientCredentials struct {
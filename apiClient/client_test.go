@@ -4,6 +4,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -21,13 +23,11 @@ func TestCredentialsPrecedence(t *testing.T) {
 		{
 			"Config has credentials",
 			ClientConfig{
-				ClientCredentials{
+				Credentials: ClientCredentials{
 					"xxbd857-XXXX-XXXX-XXXX-xxxxx039ff1x",
 					"36xxb4f-XXXX-XXXX-XXXX-c91f44axx4f6",
 					"https://example.com/",
 				},
-				"",
-				"",
 			},
 			expected{
 				true,
@@ -41,13 +41,12 @@ func TestCredentialsPrecedence(t *testing.T) {
 		{
 			"Config has profile",
 			ClientConfig{
-				ClientCredentials{
+				Credentials: ClientCredentials{
 					"",
 					"",
 					"",
 				},
-				"",
-				"test",
+				Profile: "test",
 			},
 			expected{
 				true,
@@ -61,13 +60,12 @@ func TestCredentialsPrecedence(t *testing.T) {
 		{
 			"Empty Config",
 			ClientConfig{
-				ClientCredentials{
+				Credentials: ClientCredentials{
 					"",
 					"",
 					"",
 				},
-				"",
-				"test",
+				Profile: "test",
 			},
 			expected{
 				true,
@@ -86,3 +84,74 @@ func TestCredentialsPrecedence(t *testing.T) {
 		assert.ObjectsAreEqual(test.expected.Creds, credentials)
 	}
 }
+
+func TestCredentialProviders(t *testing.T) {
+	dir := t.TempDir()
+	accessKeyFile := filepath.Join(dir, "access_key")
+	secretKeyFile := filepath.Join(dir, "secret_key")
+	assert.NoError(t, os.WriteFile(accessKeyFile, []byte("file-access-key\n"), 0600))
+	assert.NoError(t, os.WriteFile(secretKeyFile, []byte("file-secret-key\n"), 0600))
+
+	credentials, err := getCredentialsByPrecedence(ClientConfig{
+		Credentials:   ClientCredentials{Workspace: "https://example.com/"},
+		AccessKeyFile: accessKeyFile,
+		SecretKeyFile: secretKeyFile,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ClientCredentials{"file-access-key", "file-secret-key", "https://example.com/"}, credentials)
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	credentials, err = getCredentialsByPrecedence(ClientConfig{
+		CredentialProcess: `echo '{"accessKey": "process-access-key", "secretKey": "process-secret-key", "workspace": "https://example.com/"}'`,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ClientCredentials{"process-access-key", "process-secret-key", "https://example.com/"}, credentials)
+
+	_, err = getCredentialsByPrecedence(ClientConfig{CredentialProcess: "echo not-json"})
+	assert.Error(t, err)
+}
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	credentialsPath := filepath.Join(dir, "credentials.yml")
+	assert.NoError(t, os.WriteFile(credentialsPath, []byte(`default:
+  accessKey: default-access-key
+  secretKey: default-secret-key
+  workspace: https://example.com/
+proxied:
+  accessKey: proxied-access-key
+  secretKey: proxied-secret-key
+  workspace: https://example.com/
+  insecureSkipVerify: true
+  proxy: http://proxy.example.com:3128
+incomplete:
+  accessKey: incomplete-access-key
+`), 0600))
+
+	profile, err := loadProfile(credentialsPath, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "default-access-key", profile.AccessKey)
+
+	profile, err = loadProfile(credentialsPath, "proxied")
+	assert.NoError(t, err)
+	assert.True(t, profile.InsecureSkipVerify)
+	assert.Equal(t, "http://proxy.example.com:3128", profile.Proxy)
+
+	var profileErr *ProfileError
+	_, err = loadProfile(credentialsPath, "missing")
+	assert.ErrorAs(t, err, &profileErr)
+	assert.Equal(t, []string{"default", "incomplete", "proxied"}, profileErr.Available)
+
+	_, err = loadProfile(credentialsPath, "incomplete")
+	assert.ErrorAs(t, err, &profileErr)
+	assert.Equal(t, 11, profileErr.Line)
+
+	malformedPath := filepath.Join(dir, "malformed.yml")
+	assert.NoError(t, os.WriteFile(malformedPath, []byte("default:\n  accessKey: a\n\tsecretKey: b\n"), 0600))
+	_, err = loadProfile(malformedPath, "default")
+	assert.ErrorAs(t, err, &profileErr)
+	assert.NotZero(t, profileErr.Line)
+	assert.Contains(t, err.Error(), malformedPath)
+}
@@ -17,3 +17,38 @@ func (client *Client) ReadControl(args string) (*Control, error) {
 
 	return &control, nil
 }
+
+// RunControl triggers a run of the control and returns the id of the process running it
+func (client *Client) RunControl(id string) (string, error) {
+	query := runControlMutation()
+	responseData := &RunControlResponse{}
+	variables := map[string]interface{}{
+		"input": map[string]string{
+			"id": id,
+		},
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return "", fmt.Errorf("error running control %s: %s", id, err.Error())
+	}
+	return responseData.Control.Process.Turbot.Id, nil
+}
+
+// RunQuickAction runs the quick action on the resource and returns the id of the process running it
+func (client *Client) RunQuickAction(resourceId, actionUri string) (string, error) {
+	query := runQuickActionMutation()
+	responseData := &RunQuickActionResponse{}
+	variables := map[string]interface{}{
+		"input": map[string]string{
+			"resourceId": resourceId,
+			"actionUri":  actionUri,
+		},
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return "", fmt.Errorf("error running quick action %s on resource %s: %s", actionUri, resourceId, err.Error())
+	}
+	return responseData.Action.Process.Turbot.Id, nil
+}
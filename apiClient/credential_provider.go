@@ -0,0 +1,136 @@
+package apiClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// credentialProcessOutput is the JSON printed to stdout by a credential_process command
+type credentialProcessOutput struct {
+	AccessKey  string     `json:"accessKey"`
+	SecretKey  string     `json:"secretKey"`
+	Workspace  string     `json:"workspace"`
+	Expiration *time.Time `json:"expiration"`
+}
+
+type cachedProcessCredentials struct {
+	credentials ClientCredentials
+	expiration  *time.Time
+}
+
+// Credentials returned by credential_process commands, keyed by command
+var (
+	processCredentialsCache      = map[string]cachedProcessCredentials{}
+	processCredentialsCacheMutex sync.Mutex
+)
+
+// usesCredentialProcess reports whether getCredentialsByPrecedence takes the
+// keys from the credential_process for this config
+func usesCredentialProcess(config ClientConfig) bool {
+	if len(config.CredentialProcess) == 0 || CredentialsSet(config.Credentials) {
+		return false
+	}
+	fileCredentials, err := getFileCredentials(config)
+	return err != nil || !CredentialsSet(fileCredentials)
+}
+
+// getFileCredentials reads the access key and secret key from the files given
+// in the config. Values already set in the config credentials are kept.
+func getFileCredentials(config ClientConfig) (ClientCredentials, error) {
+	credentials := config.Credentials
+	if len(credentials.AccessKey) == 0 && len(config.AccessKeyFile) != 0 {
+		accessKey, err := readSecretFile(config.AccessKeyFile)
+		if err != nil {
+			return ClientCredentials{}, err
+		}
+		credentials.AccessKey = accessKey
+	}
+	if len(credentials.SecretKey) == 0 && len(config.SecretKeyFile) != 0 {
+		secretKey, err := readSecretFile(config.SecretKeyFile)
+		if err != nil {
+			return ClientCredentials{}, err
+		}
+		credentials.SecretKey = secretKey
+	}
+	return credentials, nil
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file %s: %s", path, err.Error())
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// getProcessCredentials runs the credential_process command given in the
+// config. The result is cached until it expires, or for the life of the plugin
+// if the command does not return an expiration. Values already set in the
+// config credentials, e.g. the workspace, are kept.
+func getProcessCredentials(config ClientConfig) (ClientCredentials, error) {
+	processCredentialsCacheMutex.Lock()
+	defer processCredentialsCacheMutex.Unlock()
+
+	command := config.CredentialProcess
+	cached, ok := processCredentialsCache[command]
+	if !ok || (cached.expiration != nil && time.Now().After(*cached.expiration)) {
+		output, err := runCredentialProcess(command)
+		if err != nil {
+			return ClientCredentials{}, err
+		}
+		cached = cachedProcessCredentials{
+			credentials: ClientCredentials{
+				AccessKey: output.AccessKey,
+				SecretKey: output.SecretKey,
+				Workspace: output.Workspace,
+			},
+			expiration: output.Expiration,
+		}
+		processCredentialsCache[command] = cached
+	}
+
+	credentials := config.Credentials
+	if len(credentials.AccessKey) == 0 {
+		credentials.AccessKey = cached.credentials.AccessKey
+	}
+	if len(credentials.SecretKey) == 0 {
+		credentials.SecretKey = cached.credentials.SecretKey
+	}
+	if len(credentials.Workspace) == 0 {
+		credentials.Workspace = cached.credentials.Workspace
+	}
+	return credentials, nil
+}
+
+func runCredentialProcess(command string) (credentialProcessOutput, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = os.Environ()
+
+	if err := cmd.Run(); err != nil {
+		return credentialProcessOutput{}, fmt.Errorf("credential_process %q failed: %s: %s", command, err.Error(), strings.TrimSpace(stderr.String()))
+	}
+
+	output := credentialProcessOutput{}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return credentialProcessOutput{}, fmt.Errorf("credential_process %q returned invalid JSON: %s", command, err.Error())
+	}
+	if len(output.AccessKey) == 0 || len(output.SecretKey) == 0 {
+		return credentialProcessOutput{}, fmt.Errorf("credential_process %q did not return accessKey and secretKey", command)
+	}
+	return output, nil
+}
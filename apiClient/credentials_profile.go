@@ -0,0 +1,113 @@
+package apiClient
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-yaml/yaml"
+)
+
+// CredentialsProfile is a named profile in the Turbot credentials file
+type CredentialsProfile struct {
+	AccessKey          string `yaml:"accessKey"`
+	SecretKey          string `yaml:"secretKey"`
+	Workspace          string `yaml:"workspace"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+	Proxy              string `yaml:"proxy"`
+}
+
+func (p CredentialsProfile) credentials() ClientCredentials {
+	return ClientCredentials{AccessKey: p.AccessKey, SecretKey: p.SecretKey, Workspace: p.Workspace}
+}
+
+// ProfileError is returned when a profile can't be loaded from the credentials file
+type ProfileError struct {
+	Path    string
+	Profile string
+	// Line in the credentials file the error relates to, 0 if unknown
+	Line int
+	// Profiles available in the credentials file, set when the profile is missing
+	Available []string
+	Err       error
+}
+
+func (e *ProfileError) Error() string {
+	location := e.Path
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", e.Path, e.Line)
+	}
+	msg := fmt.Sprintf("failed to load profile %s from credentials file %s: %s", e.Profile, location, e.Err.Error())
+	if e.Available != nil {
+		msg += fmt.Sprintf(" (available profiles: %s)", strings.Join(e.Available, ", "))
+	}
+	return msg
+}
+
+func (e *ProfileError) Unwrap() error {
+	return e.Err
+}
+
+var yamlErrorLineRegex = regexp.MustCompile(`line ([0-9]+)`)
+
+// loadProfile loads and validates a single profile from the credentials file
+func loadProfile(credentialsPath, profile string) (CredentialsProfile, error) {
+	// if no profile specified, use default
+	if len(profile) == 0 {
+		profile = "default"
+	}
+	yamlFile, err := os.ReadFile(credentialsPath)
+	if err != nil {
+		return CredentialsProfile{}, &ProfileError{Path: credentialsPath, Profile: profile, Err: err}
+	}
+
+	var profiles = map[string]CredentialsProfile{}
+	err = yaml.Unmarshal(yamlFile, &profiles)
+	if err != nil {
+		profileErr := &ProfileError{Path: credentialsPath, Profile: profile, Err: err}
+		if match := yamlErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+			profileErr.Line, _ = strconv.Atoi(match[1])
+		}
+		return CredentialsProfile{}, profileErr
+	}
+
+	p, ok := profiles[profile]
+	if !ok {
+		available := []string{}
+		for name := range profiles {
+			available = append(available, name)
+		}
+		sort.Strings(available)
+		return CredentialsProfile{}, &ProfileError{Path: credentialsPath, Profile: profile, Available: available, Err: fmt.Errorf("profile not found")}
+	}
+
+	missing := []string{}
+	if len(p.AccessKey) == 0 {
+		missing = append(missing, "accessKey")
+	}
+	if len(p.SecretKey) == 0 {
+		missing = append(missing, "secretKey")
+	}
+	if len(p.Workspace) == 0 {
+		missing = append(missing, "workspace")
+	}
+	if len(missing) > 0 {
+		return CredentialsProfile{}, &ProfileError{Path: credentialsPath, Profile: profile, Line: profileLine(yamlFile, profile), Err: fmt.Errorf("missing %s", strings.Join(missing, ", "))}
+	}
+
+	return p, nil
+}
+
+// profileLine returns the line the profile is defined on in the credentials file, or 0 if not found
+func profileLine(yamlFile []byte, profile string) int {
+	profileRegex := regexp.MustCompile(`^['"]?` + regexp.QuoteMeta(profile) + `['"]?\s*:`)
+	for i, line := range strings.Split(string(yamlFile), "\n") {
+		if profileRegex.MatchString(line) {
+			return i + 1
+		}
+	}
+	return 0
+}
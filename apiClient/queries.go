@@ -551,6 +551,30 @@ control(%s){
 }`, args)
 }
 
+func runControlMutation() string {
+	return `mutation RunControl($input: RunControlInput!) {
+	control: runControl(input: $input) {
+		process {
+			turbot {
+				id
+			}
+		}
+	}
+}`
+}
+
+func runQuickActionMutation() string {
+	return `mutation RunQuickAction($input: RunQuickActionInput!) {
+	action: runQuickAction(input: $input) {
+		process {
+			turbot {
+				id
+			}
+		}
+	}
+}`
+}
+
 // group profile
 func createGroupProfileMutation(properties []interface{}) string {
 	return fmt.Sprintf(`mutation createGroupProfile($input: CreateGroupProfileInput!) {
@@ -95,6 +95,22 @@ type Control struct {
 	Turbot map[string]string
 }
 
+type ProcessReference struct {
+	Process struct {
+		Turbot struct {
+			Id string
+		}
+	}
+}
+
+type RunControlResponse struct {
+	Control ProcessReference
+}
+
+type RunQuickActionResponse struct {
+	Action ProcessReference
+}
+
 // is the validation response successful?
 func (response *ValidationResponse) isValid() bool {
 	return response.Schema.QueryType.Name == "Query"
@@ -22,6 +22,14 @@ func FailedValidationError(err error) bool {
 	return expectedErr.Match([]byte(err.Error()))
 }
 
+// UnknownFieldError reports whether the query was rejected because it selects a
+// field the schema doesn't have
+func UnknownFieldError(err error) bool {
+	unknownFieldErr := "(?i)cannot query field"
+	expectedErr := regexp.MustCompile(unknownFieldErr)
+	return expectedErr.Match([]byte(err.Error()))
+}
+
 func ExtractErrorCode(err error) (int, error) {
 	// error returned from machinebox/graphql is of graphql type
 	// errorNon200Template = "graphql: server returned a non-200 status code: 503"
@@ -0,0 +1,264 @@
+package turbot
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func extractActionTypeFromHydrateItem(h *plugin.HydrateData) (ActionType, error) {
+    if actionType, ok := h.Item.(ActionType); ok {
+        return actionType, nil
+    } else {
+        return ActionType{}, fmt.Errorf("unable to parse hydrate item %v as an ActionType", h.Item)
+    }
+}
+
+func actionTypeHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Turbot.ID, nil
+}
+
+func actionTypeHydrateUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.URI, nil
+}
+
+func actionTypeHydrateTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Title, nil
+}
+
+func actionTypeHydrateTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Trunk.Title, nil
+}
+
+func actionTypeHydrateDescription(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Description, nil
+}
+
+func actionTypeHydrateTargets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Targets, nil
+}
+
+func actionTypeHydratePermissionTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.PermissionType, nil
+}
+
+func actionTypeHydratePermissionLevelUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.PermissionLevel, nil
+}
+
+func actionTypeHydrateAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Turbot.Akas, nil
+}
+
+func actionTypeHydrateIcon(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Icon, nil
+}
+
+func actionTypeHydrateModUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.ModURI, nil
+}
+
+func actionTypeHydrateParentId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Turbot.ParentID, nil
+}
+
+func actionTypeHydratePath(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Turbot.Path, nil
+}
+
+func actionTypeHydrateCreateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Turbot.CreateTimestamp, nil
+}
+
+func actionTypeHydrateUpdateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Turbot.UpdateTimestamp, nil
+}
+
+func actionTypeHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    actionType, err := extractActionTypeFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return actionType.Turbot.VersionID, nil
+}
+
+func extractActionFromHydrateItem(h *plugin.HydrateData) (Action, error) {
+    if action, ok := h.Item.(Action); ok {
+        return action, nil
+    } else {
+        return Action{}, fmt.Errorf("unable to parse hydrate item %v as an Action", h.Item)
+    }
+}
+
+func actionHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Turbot.ID, nil
+}
+
+func actionHydrateCreateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Turbot.CreateTimestamp, nil
+}
+
+func actionHydrateActionTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Action.Type.URI, nil
+}
+
+func actionHydrateActionTypeTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Action.Type.Trunk.Title, nil
+}
+
+func actionHydrateState(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Action.State, nil
+}
+
+func actionHydrateReason(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Action.Reason, nil
+}
+
+func actionHydrateMessage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Message, nil
+}
+
+func actionHydrateActorIdentityId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Actor.Identity.Turbot.ID, nil
+}
+
+func actionHydrateActorIdentityTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Actor.Identity.Trunk.Title, nil
+}
+
+func actionHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Turbot.ResourceID, nil
+}
+
+func actionHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Resource.Trunk.Title, nil
+}
+
+func actionHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Resource.Type.URI, nil
+}
+
+func actionHydrateProcessId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Turbot.ProcessID, nil
+}
+
+func actionHydrateControlId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    action, err := extractActionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return action.Turbot.ControlID, nil
+}
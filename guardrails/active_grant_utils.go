@@ -4,10 +4,102 @@ import (
     "context"
     "fmt"
     "slices"
+    "strings"
+    "time"
 
+    "github.com/turbot/steampipe-plugin-guardrails/apiClient"
+    "github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
     "github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
+// grantQualKeyColumns are the optional key columns shared by the grant tables
+// that are pushed down to Guardrails as filters by appendGrantQualFilters
+func grantQualKeyColumns() []*plugin.KeyColumn {
+    return []*plugin.KeyColumn{
+        {Name: "resource_id", Require: plugin.Optional},
+        {Name: "resource_type_uri", Require: plugin.Optional},
+        {Name: "identity_email", Require: plugin.Optional},
+        {Name: "identity_profile_id", Require: plugin.Optional},
+        {Name: "identity_status", Require: plugin.Optional},
+        {Name: "level_uri", Require: plugin.Optional},
+    }
+}
+
+// grantIdentityQualFields are the identity qual columns and the identity data
+// fields they are read from
+var grantIdentityQualFields = []struct {
+    Column string
+    Field  string
+}{
+    {"identity_email", "email"},
+    {"identity_profile_id", "profileId"},
+    {"identity_status", "status"},
+}
+
+// appendGrantQualFilters adds the filters for the grant qual columns. Grants
+// can only be filtered by identity id, so the identities matching the identity
+// quals are looked up first. It returns false if no identity matches, in which
+// case there are no grants to list.
+func appendGrantQualFilters(ctx context.Context, conn *apiClient.Client, quals map[string]*proto.QualValue, filters []string) ([]string, bool, error) {
+    if quals["resource_id"] != nil {
+        filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "resource_id", "int64")))
+    }
+    if quals["resource_type_uri"] != nil {
+        filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+    }
+    if quals["level_uri"] != nil {
+        filters = append(filters, fmt.Sprintf("levelId:%s", getQualListValues(ctx, quals, "level_uri", "string")))
+    }
+
+    identityFilters := []string{}
+    for _, f := range grantIdentityQualFields {
+        if quals[f.Column] != nil {
+            identityFilters = append(identityFilters, fmt.Sprintf("$.%s:%s", f.Field, getQualListValues(ctx, quals, f.Column, "string")))
+        }
+    }
+    if len(identityFilters) > 0 {
+        identityIds, err := listGrantIdentityIds(conn, identityFilters)
+        if err != nil {
+            return nil, false, err
+        }
+        if len(identityIds) == 0 {
+            return nil, false, nil
+        }
+        filters = append(filters, fmt.Sprintf("identityId:%s", strings.Join(identityIds, ",")))
+    }
+    return filters, true, nil
+}
+
+// listGrantIdentityIds returns the ids of the user and group profiles matching
+// all of the filters
+func listGrantIdentityIds(conn *apiClient.Client, identityFilters []string) ([]string, error) {
+    filters := []string{fmt.Sprintf("resourceTypeId:'%s','%s' resourceTypeLevel:self", profileResourceTypeUri, groupProfileResourceTypeUri)}
+    filters = append(filters, identityFilters...)
+    filters = append(filters, "limit:5000")
+    variables := map[string]interface{}{
+        "filter":     filters,
+        "next_token": "",
+    }
+
+    ids := []string{}
+    for {
+        result := &ResourcesResponse{}
+        err := conn.DoRequest(queryGrantIdentityIds, variables, result)
+        if err != nil {
+            return nil, err
+        }
+        for _, r := range result.Resources.Items {
+            ids = append(ids, r.Turbot.ID)
+        }
+        if result.Resources.Paging.Next == "" {
+            break
+        }
+        variables["next_token"] = result.Resources.Paging.Next
+    }
+
+    return ids, nil
+}
+
 func appendGrantColumnIncludes(m *map[string]interface{}, cols []string) {
     (*m)["includeGrantTurbotId"] = slices.Contains(cols, "id")
     (*m)["includeGrantResourceId"] = slices.Contains(cols, "resource_id")
@@ -30,6 +122,8 @@ func appendGrantColumnIncludes(m *map[string]interface{}, cols []string) {
     (*m)["includeGrantTurbotTimestamp"] = slices.Contains(cols, "timestamp")
     (*m)["includeGrantTurbotUpdateTimestamp"] = slices.Contains(cols, "update_timestamp")
     (*m)["includeGrantTurbotVersionId"] = slices.Contains(cols, "version_id")
+    (*m)["includeGrantValidFromTimestamp"] = slices.Contains(cols, "valid_from_timestamp")
+    (*m)["includeGrantValidToTimestamp"] = slices.Contains(cols, "valid_to_timestamp") || slices.Contains(cols, "expires_in_seconds")
 
     // columns which are not part of the table
     (*m)["includeGrantResourceAkas"] = slices.Contains(cols, "resource_akas")
@@ -218,6 +312,56 @@ func grantHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *plugin.H
     return grant.Turbot.VersionID, nil
 }
 
+func grantHydrateValidFromTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    grant, err := extractGrantFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return grant.ValidFromTimestamp, nil
+}
+
+func grantHydrateValidToTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    grant, err := extractGrantFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return grant.ValidToTimestamp, nil
+}
+
+func grantHydrateExpiresInSeconds(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    grant, err := extractGrantFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    if grant.ValidToTimestamp == nil || *grant.ValidToTimestamp == "" {
+        return nil, nil
+    }
+    validTo, err := time.Parse(time.RFC3339, *grant.ValidToTimestamp)
+    if err != nil {
+        return nil, err
+    }
+    return int64(time.Until(validTo).Seconds()), nil
+}
+
+func grantHydrateIsActivated(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    grant, err := extractGrantFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return len(grant.ActiveGrantResourceIDs) > 0, nil
+}
+
+func grantHydrateActiveGrantResourceIds(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    grant, err := extractGrantFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    if grant.ActiveGrantResourceIDs == nil {
+        return []string{}, nil
+    }
+    return grant.ActiveGrantResourceIDs, nil
+}
+
 func appendActiveGrantColumnIncludes(m *map[string]interface{}, cols []string) {
     (*m)["includeActiveGrantId"] = slices.Contains(cols, "grant_id")
     (*m)["includeActiveGrantResourceId"] = slices.Contains(cols, "resource_id")
@@ -428,3 +572,235 @@ func activeGrantHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *pl
     }
     return activeGrant.Turbot.VersionID, nil
 }
+
+func extractEffectivePermissionFromHydrateItem(h *plugin.HydrateData) (EffectivePermission, error) {
+    if permission, ok := h.Item.(EffectivePermission); ok {
+        return permission, nil
+    } else {
+        return EffectivePermission{}, fmt.Errorf("unable to parse hydrate item %v as an EffectivePermission", h.Item)
+    }
+}
+
+func effectivePermissionHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.ResourceID, nil
+}
+
+func effectivePermissionHydrateUserEmail(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.User.Email, nil
+}
+
+func effectivePermissionHydrateUserTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.User.Trunk.Title, nil
+}
+
+func effectivePermissionHydrateUserProfileId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.User.ProfileID, nil
+}
+
+func effectivePermissionHydrateLevelUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.Grant.Grant.Level.URI, nil
+}
+
+func effectivePermissionHydrateLevelTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.Grant.Grant.Level.Title, nil
+}
+
+func effectivePermissionHydrateIsInherited(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.Inherited, nil
+}
+
+func effectivePermissionHydrateGrantId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.Grant.Grant.Turbot.ID, nil
+}
+
+func effectivePermissionHydrateGrantResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.Grant.Resource.Turbot.ID, nil
+}
+
+func effectivePermissionHydrateGrantResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return p.Grant.Resource.Trunk.Title, nil
+}
+
+func effectivePermissionHydrateViaGroup(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    p, err := extractEffectivePermissionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    if p.Group == nil {
+        return nil, nil
+    }
+    return p.Group.Trunk.Title, nil
+}
+
+func extractPermissionDefinitionFromHydrateItem(h *plugin.HydrateData) (PermissionDefinition, error) {
+    if permission, ok := h.Item.(PermissionDefinition); ok {
+        return permission, nil
+    } else {
+        return PermissionDefinition{}, fmt.Errorf("unable to parse hydrate item %v as a PermissionDefinition", h.Item)
+    }
+}
+
+func permissionHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Turbot.ID, nil
+}
+
+func permissionHydrateUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.URI, nil
+}
+
+func permissionHydrateTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Title, nil
+}
+
+func permissionHydrateTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Trunk.Title, nil
+}
+
+func permissionHydrateDescription(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Description, nil
+}
+
+func permissionHydrateModUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.ModURI, nil
+}
+
+func permissionHydrateAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Turbot.Akas, nil
+}
+
+func permissionHydrateParentId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Turbot.ParentID, nil
+}
+
+func permissionHydratePath(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Turbot.Path, nil
+}
+
+func permissionHydrateCreateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Turbot.CreateTimestamp, nil
+}
+
+func permissionHydrateUpdateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Turbot.UpdateTimestamp, nil
+}
+
+func permissionHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Turbot.VersionID, nil
+}
+
+func permissionHydrateRank(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    permission, err := extractPermissionDefinitionFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return permission.Rank, nil
+}
+
+// permissionLevelRanks returns the rank of each permission level, keyed by id.
+// The rank is the number of other levels below the level in the hierarchy, so
+// levels that include others, like Owner, rank higher than the levels they
+// include.
+func permissionLevelRanks(levels []PermissionDefinition) map[string]int {
+    ranks := map[string]int{}
+    for _, l := range levels {
+        ranks[l.Turbot.ID] = 0
+    }
+
+    // Each level adds one to the rank of every level above it in its path
+    for _, l := range levels {
+        for _, id := range strings.Split(l.Turbot.Path, ".") {
+            if _, ok := ranks[id]; ok && id != l.Turbot.ID {
+                ranks[id]++
+            }
+        }
+    }
+    return ranks
+}
@@ -0,0 +1,157 @@
+package turbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// cataloguePage is one page of a type catalogue list, like resource, control
+// or policy types, which rarely change
+type cataloguePage[T any] interface {
+	*T
+	nextToken() string
+}
+
+func (r *ResourceTypesResponse) nextToken() string { return r.ResourceTypes.Paging.Next }
+func (r *ControlTypesResponse) nextToken() string  { return r.ControlTypes.Paging.Next }
+func (r *PolicyTypesResponse) nextToken() string   { return r.PolicyTypes.Paging.Next }
+
+// catalogueRequest is a type catalogue list request, covering all its pages
+type catalogueRequest struct {
+	Query     string
+	Variables map[string]interface{}
+	NewPage   func() interface{}
+	NextToken func(interface{}) string
+}
+
+// Memoize fixes the TTL when the function is wrapped, so there is one memoized
+// function per TTL in use by the connections
+var catalogueRequestMemoized sync.Map
+
+func getCatalogueRequestMemoized(ttl time.Duration) plugin.HydrateFunc {
+	if f, ok := catalogueRequestMemoized.Load(ttl); ok {
+		return f.(plugin.HydrateFunc)
+	}
+	f := plugin.HydrateFunc(getCataloguePagesUncached).Memoize(memoize.WithCacheKeyFunction(getCatalogueRequestCacheKey), memoize.WithTtl(ttl))
+	actual, _ := catalogueRequestMemoized.LoadOrStore(ttl, f)
+	return actual.(plugin.HydrateFunc)
+}
+
+// Build a cache key for the request. The variables hold the filter and the
+// includes for the selected columns, but not the page, so there is one entry
+// per distinct list.
+func getCatalogueRequestCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	req := h.Item.(catalogueRequest)
+	variables, err := json.Marshal(req.Variables)
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("%s-%s", d.Table.Name, variables), nil
+}
+
+// getCataloguePagesUncached fetches every page of the request
+func getCataloguePagesUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	pages := []interface{}{}
+	err := fetchCataloguePages(ctx, d, h.Item.(catalogueRequest), func(page interface{}) bool {
+		pages = append(pages, page)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// fetchCataloguePages requests the pages in turn, until there are no more or
+// stream returns false
+func fetchCataloguePages(ctx context.Context, d *plugin.QueryData, req catalogueRequest, stream func(interface{}) bool) error {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	variables := map[string]interface{}{}
+	for k, v := range req.Variables {
+		variables[k] = v
+	}
+	variables["next_token"] = ""
+
+	for {
+		page := req.NewPage()
+		err = conn.DoRequest(req.Query, variables, page)
+		if err != nil {
+			return err
+		}
+		if !stream(page) {
+			return nil
+		}
+		next := req.NextToken(page)
+		if next == "" {
+			return nil
+		}
+		variables["next_token"] = next
+	}
+}
+
+// getTypeCacheTtl returns how long type catalogue responses are cached for the
+// connection, zero disables the cache
+func getTypeCacheTtl(d *plugin.QueryData) time.Duration {
+	config := GetConfig(d.Connection)
+	if config.TypeCacheTtl == nil {
+		return 0
+	}
+	return time.Duration(*config.TypeCacheTtl) * time.Second
+}
+
+// getCataloguePageLimit returns the page size for a type catalogue list. Cached
+// catalogues are fetched whole, so the page size is only reduced to the query
+// limit when the cache is off.
+func getCataloguePageLimit(d *plugin.QueryData) int64 {
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil && getTypeCacheTtl(d) == 0 {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+	return pageLimit
+}
+
+// listCatalogue runs a type catalogue query, calling stream with each page
+// until it returns false. If type_cache_ttl is set, all the pages are fetched
+// once and returned from the connection cache to queries with the same filter
+// and columns within the TTL. Cached pages are shared and must not be modified.
+func listCatalogue[T any, P cataloguePage[T]](ctx context.Context, d *plugin.QueryData, query string, variables map[string]interface{}, stream func(*T) bool) error {
+	req := catalogueRequest{
+		Query:     query,
+		Variables: variables,
+		NewPage:   func() interface{} { return new(T) },
+		NextToken: func(page interface{}) string { return P(page.(*T)).nextToken() },
+	}
+
+	ttl := getTypeCacheTtl(d)
+	if ttl == 0 {
+		return fetchCataloguePages(ctx, d, req, func(page interface{}) bool {
+			return stream(page.(*T))
+		})
+	}
+
+	pages, err := getCatalogueRequestMemoized(ttl)(ctx, d, &plugin.HydrateData{Item: req})
+	if err != nil {
+		return err
+	}
+	for _, page := range pages.([]interface{}) {
+		if !stream(page.(*T)) {
+			break
+		}
+	}
+	return nil
+}
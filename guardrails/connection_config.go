@@ -1,6 +1,9 @@
 package turbot
 
 import (
+	"fmt"
+
+	"github.com/turbot/steampipe-plugin-guardrails/apiClient"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
@@ -10,6 +13,27 @@ type guardrailsConfig struct {
 	SecretKey          *string `hcl:"secret_key"`
 	Workspace          *string `hcl:"workspace"`
 	InsecureSkipVerify *bool   `hcl:"insecure_skip_verify,optional"`
+	CredentialProcess  *string `hcl:"credential_process,optional"`
+	AccessKeyFile      *string `hcl:"access_key_file,optional"`
+	SecretKeyFile      *string `hcl:"secret_key_file,optional"`
+
+	// HTTP transport
+	ProxyUrl              *string `hcl:"proxy_url,optional"`
+	CACertFile            *string `hcl:"ca_cert_file,optional"`
+	ClientCertFile        *string `hcl:"client_cert_file,optional"`
+	ClientKeyFile         *string `hcl:"client_key_file,optional"`
+	MaxIdleConnections    *int    `hcl:"max_idle_connections,optional"`
+	MaxConnectionsPerHost *int    `hcl:"max_connections_per_host,optional"`
+	IdleConnectionTimeout *int    `hcl:"idle_connection_timeout,optional"`
+	KeepAliveInterval     *int    `hcl:"keep_alive_interval,optional"`
+
+	// Seconds to cache the resource, control and policy type catalogues for
+	TypeCacheTtl *int `hcl:"type_cache_ttl,optional"`
+
+	// Action tables change the workspace, so they are disabled by default
+	AllowActions *bool `hcl:"allow_actions,optional"`
+	// Most actions a single query may run
+	MaxActions *int `hcl:"max_actions,optional"`
 }
 
 func ConfigInstance() interface{} {
@@ -24,3 +48,41 @@ func GetConfig(connection *plugin.Connection) guardrailsConfig {
 	config, _ := connection.Config.(guardrailsConfig)
 	return config
 }
+
+// validateConnectionConfig checks the connection config before a client is
+// created, so a bad config gives a clear error rather than a failed request.
+// It does not call the Guardrails API, use the guardrails_connection table for that.
+func validateConnectionConfig(config guardrailsConfig) error {
+	if (config.AccessKey == nil) != (config.SecretKey == nil) {
+		return fmt.Errorf("access_key and secret_key must be set together")
+	}
+	if (config.AccessKeyFile == nil) != (config.SecretKeyFile == nil) {
+		return fmt.Errorf("access_key_file and secret_key_file must be set together")
+	}
+	for name, value := range map[string]*int{
+		"max_idle_connections":     config.MaxIdleConnections,
+		"max_connections_per_host": config.MaxConnectionsPerHost,
+		"idle_connection_timeout":  config.IdleConnectionTimeout,
+		"type_cache_ttl":           config.TypeCacheTtl,
+		"max_actions":              config.MaxActions,
+	} {
+		if value != nil && *value < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+
+	// Check the CA, client certificate and proxy settings load
+	if _, err := buildTransport(config, nil); err != nil {
+		return err
+	}
+
+	// Check the named profile exists and is complete, unless credentials are
+	// given some other way
+	if config.Profile != nil && config.AccessKey == nil && config.AccessKeyFile == nil && config.CredentialProcess == nil {
+		if _, err := apiClient.GetCredentialsProfile(getClientConfig(config)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
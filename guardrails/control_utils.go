@@ -171,6 +171,7 @@ func controlColumnIncludes(m *map[string]interface{}, cols []string) {
     (*m)["includeControlResourceId"] = slices.Contains(cols, "resource_id")
     (*m)["includeControlTypeTrunkTitle"] = slices.Contains(cols, "control_type_trunk_title")
     (*m)["includeControlResourceTrunkTitle"] = slices.Contains(cols, "resource_trunk_title")
+    (*m)["includeControlResourceAkas"] = slices.Contains(cols, "aka")
     (*m)["includeControlTypeUri"] = slices.Contains(cols, "control_type_uri")
     (*m)["includeControlTypeId"] = slices.Contains(cols, "control_type_id")
     (*m)["includeControlTimestamp"] = slices.Contains(cols, "timestamp")
@@ -237,6 +238,14 @@ func controlHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData,
     return control.Resource.Trunk.Title, nil
 }
 
+func controlHydrateResourceAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    control, err := extractControlFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return control.Resource.Turbot.Akas, nil
+}
+
 func controlHydrateControlTypeTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
     control, err := extractControlFromHydrateItem(h)
     if err != nil {
@@ -308,3 +317,299 @@ func controlHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *
     }
     return control.Resource.Type.URI, nil
 }
+
+func extractControlSummaryRowFromHydrateItem(h *plugin.HydrateData) (ControlSummaryRow, error) {
+    if row, ok := h.Item.(ControlSummaryRow); ok {
+        return row, nil
+    } else {
+        return ControlSummaryRow{}, fmt.Errorf("unable to parse hydrate item %v as a ControlSummaryRow", h.Item)
+    }
+}
+
+func controlSummaryHydrateControlTypeId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    row, err := extractControlSummaryRowFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return row.Type.Turbot.ID, nil
+}
+
+func controlSummaryHydrateControlTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    row, err := extractControlSummaryRowFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return row.Type.URI, nil
+}
+
+func controlSummaryHydrateControlTypeTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    row, err := extractControlSummaryRowFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return row.Type.Trunk.Title, nil
+}
+
+func controlSummaryHydrateState(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    row, err := extractControlSummaryRowFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return row.State, nil
+}
+
+func controlSummaryHydrateCount(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    row, err := extractControlSummaryRowFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return row.Count, nil
+}
+
+// controlStates are the states a control can be in, in the order they are
+// shown in the Guardrails console
+var controlStates = []string{"alarm", "error", "invalid", "ok", "skipped", "tbd"}
+
+// controlStateCounts returns the number of controls in each state
+func controlStateCounts(summary ControlStateSummary) map[string]int64 {
+    return map[string]int64{
+        "alarm":   summary.Alarm,
+        "error":   summary.Error,
+        "invalid": summary.Invalid,
+        "ok":      summary.Ok,
+        "skipped": summary.Skipped,
+        "tbd":     summary.Tbd,
+    }
+}
+
+func extractResourceControlSummaryFromHydrateItem(h *plugin.HydrateData) (ResourceControlSummary, error) {
+    if summary, ok := h.Item.(ResourceControlSummary); ok {
+        return summary, nil
+    } else {
+        return ResourceControlSummary{}, fmt.Errorf("unable to parse hydrate item %v as a ResourceControlSummary", h.Item)
+    }
+}
+
+func resourceControlSummaryHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Resource.Turbot.ID, nil
+}
+
+func resourceControlSummaryHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Resource.Trunk.Title, nil
+}
+
+func resourceControlSummaryHydrateResourceTypeId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Resource.Type.Turbot.ID, nil
+}
+
+func resourceControlSummaryHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Resource.Type.URI, nil
+}
+
+func resourceControlSummaryHydrateAlarm(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Control.Alarm, nil
+}
+
+func resourceControlSummaryHydrateError(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Control.Error, nil
+}
+
+func resourceControlSummaryHydrateInvalid(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Control.Invalid, nil
+}
+
+func resourceControlSummaryHydrateOk(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Control.Ok, nil
+}
+
+func resourceControlSummaryHydrateSkipped(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Control.Skipped, nil
+}
+
+func resourceControlSummaryHydrateTbd(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Control.Tbd, nil
+}
+
+func resourceControlSummaryHydrateTotal(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceControlSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Control.Total, nil
+}
+
+func extractControlRunFromHydrateItem(h *plugin.HydrateData) (ControlRun, error) {
+    if run, ok := h.Item.(ControlRun); ok {
+        return run, nil
+    } else {
+        return ControlRun{}, fmt.Errorf("unable to parse hydrate item %v as a ControlRun", h.Item)
+    }
+}
+
+func controlRunHydrateControlId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    run, err := extractControlRunFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return run.Control.Turbot.ID, nil
+}
+
+func controlRunHydrateProcessId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    run, err := extractControlRunFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return run.ProcessID, nil
+}
+
+func controlRunHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    run, err := extractControlRunFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return run.Control.Turbot.ResourceID, nil
+}
+
+func controlRunHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    run, err := extractControlRunFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return run.Control.Resource.Trunk.Title, nil
+}
+
+func extractControlCategoryFromHydrateItem(h *plugin.HydrateData) (ControlCategory, error) {
+    if category, ok := h.Item.(ControlCategory); ok {
+        return category, nil
+    } else {
+        return ControlCategory{}, fmt.Errorf("unable to parse hydrate item %v as a ControlCategory", h.Item)
+    }
+}
+
+func controlCategoryHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Turbot.ID, nil
+}
+
+func controlCategoryHydrateUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.URI, nil
+}
+
+func controlCategoryHydrateTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Title, nil
+}
+
+func controlCategoryHydrateTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Trunk.Title, nil
+}
+
+func controlCategoryHydrateDescription(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Description, nil
+}
+
+func controlCategoryHydrateParentId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Turbot.ParentID, nil
+}
+
+func controlCategoryHydratePath(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Turbot.Path, nil
+}
+
+func controlCategoryHydrateAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Turbot.Akas, nil
+}
+
+func controlCategoryHydrateCreateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Turbot.CreateTimestamp, nil
+}
+
+func controlCategoryHydrateUpdateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Turbot.UpdateTimestamp, nil
+}
+
+func controlCategoryHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    category, err := extractControlCategoryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return category.Turbot.VersionID, nil
+}
@@ -0,0 +1,80 @@
+package turbot
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func extractFavoriteFromHydrateItem(h *plugin.HydrateData) (Favorite, error) {
+    if favorite, ok := h.Item.(Favorite); ok {
+        return favorite, nil
+    } else {
+        return Favorite{}, fmt.Errorf("unable to parse hydrate item %v as a Favorite", h.Item)
+    }
+}
+
+func favoriteHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Turbot.ID, nil
+}
+
+func favoriteHydrateIdentityId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Turbot.IdentityID, nil
+}
+
+func favoriteHydrateIdentityProfileId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Identity.ProfileID, nil
+}
+
+func favoriteHydrateIdentityTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Identity.Trunk.Title, nil
+}
+
+func favoriteHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Turbot.ResourceID, nil
+}
+
+func favoriteHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Resource.Trunk.Title, nil
+}
+
+func favoriteHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Resource.Type.URI, nil
+}
+
+func favoriteHydrateCreateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    favorite, err := extractFavoriteFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return favorite.Turbot.CreateTimestamp, nil
+}
@@ -2,6 +2,7 @@ package turbot
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/turbot/steampipe-plugin-guardrails/errors"
 
@@ -19,21 +20,48 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			ShouldIgnoreError: errors.NotFoundError,
 		},
 		DefaultTransform: transform.FromGo(),
-		TableMap: map[string]*plugin.Table{
-			"guardrails_active_grant":   tableGuardrailsActiveGrant(ctx),
-			"guardrails_control":        tableGuardrailsControl(ctx),
-			"guardrails_control_type":   tableGuardrailsControlType(ctx),
-			"guardrails_grant":          tableGuardrailsGrant(ctx),
-			"guardrails_mod_version":    tableGuardrailsModVersion(ctx),
-			"guardrails_notification":   tableGuardrailsNotification(ctx),
-			"guardrails_policy_setting": tableGuardrailsPolicySetting(ctx),
-			"guardrails_policy_type":    tableGuardrailsPolicyType(ctx),
-			"guardrails_policy_value":   tableGuardrailsPolicyValue(ctx),
-			"guardrails_query":          tableGuardrailsQuery(ctx),
-			"guardrails_resource":       tableGuardrailsResource(ctx),
-			"guardrails_resource_type":  tableGuardrailsResourceType(ctx),
-			"guardrails_smart_folder":   tableGuardrailsSmartFolder(ctx),
-			"guardrails_tag":            tableGuardrailsTag(ctx),
+		TableMapFunc: func(ctx context.Context, d *plugin.TableMapData) (map[string]*plugin.Table, error) {
+			// Fail the connection at start-up if its config is invalid
+			if err := validateConnectionConfig(GetConfig(d.Connection)); err != nil {
+				return nil, fmt.Errorf("invalid Turbot Guardrails connection config: %w", err)
+			}
+			return map[string]*plugin.Table{
+				"guardrails_action":                      tableGuardrailsAction(ctx),
+				"guardrails_action_type":                 tableGuardrailsActionType(ctx),
+				"guardrails_active_grant":                tableGuardrailsActiveGrant(ctx),
+				"guardrails_connection":                  tableGuardrailsConnection(ctx),
+				"guardrails_control":                     tableGuardrailsControl(ctx),
+				"guardrails_control_category":            tableGuardrailsControlCategory(ctx),
+				"guardrails_control_run":                 tableGuardrailsControlRun(ctx),
+				"guardrails_control_summary":             tableGuardrailsControlSummary(ctx),
+				"guardrails_control_type":                tableGuardrailsControlType(ctx),
+				"guardrails_effective_permission":        tableGuardrailsEffectivePermission(ctx),
+				"guardrails_event":                       tableGuardrailsEvent(ctx),
+				"guardrails_favorite":                    tableGuardrailsFavorite(ctx),
+				"guardrails_grant":                       tableGuardrailsGrant(ctx),
+				"guardrails_grant_pending":               tableGuardrailsGrantPending(ctx),
+				"guardrails_mod_version":                 tableGuardrailsModVersion(ctx),
+				"guardrails_notification":                tableGuardrailsNotification(ctx),
+				"guardrails_permission_level":            tableGuardrailsPermissionLevel(ctx),
+				"guardrails_permission_type":             tableGuardrailsPermissionType(ctx),
+				"guardrails_policy_setting":              tableGuardrailsPolicySetting(ctx),
+				"guardrails_policy_type":                 tableGuardrailsPolicyType(ctx),
+				"guardrails_policy_value":                tableGuardrailsPolicyValue(ctx),
+				"guardrails_policy_value_explain":        tableGuardrailsPolicyValueExplain(ctx),
+				"guardrails_query":                       tableGuardrailsQuery(ctx),
+				"guardrails_resource":                    tableGuardrailsResource(ctx),
+				"guardrails_resource_ancestor":           tableGuardrailsResourceAncestor(ctx),
+				"guardrails_resource_control_summary":    tableGuardrailsResourceControlSummary(ctx),
+				"guardrails_resource_descendant":         tableGuardrailsResourceDescendant(ctx),
+				"guardrails_resource_summary":            tableGuardrailsResourceSummary(ctx),
+				"guardrails_resource_type":               tableGuardrailsResourceType(ctx),
+				"guardrails_smart_folder":                tableGuardrailsSmartFolder(ctx),
+				"guardrails_smart_folder_attachment":     tableGuardrailsSmartFolderAttachment(ctx),
+				"guardrails_smart_folder_policy_setting": tableGuardrailsSmartFolderPolicySetting(ctx),
+				"guardrails_tag":                         tableGuardrailsTag(ctx),
+				"guardrails_watch":                       tableGuardrailsWatch(ctx),
+				"guardrails_workspace":                   tableGuardrailsWorkspace(ctx),
+			}, nil
 		},
 	}
 	return p
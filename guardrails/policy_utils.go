@@ -17,6 +17,7 @@ func appendPolicyValueColumnIncludes(m *map[string]interface{}, cols []string) {
     (*m)["includePolicyValuePrecedence"] = slices.Contains(cols, "precedence")
     (*m)["includePolicyValueTurbotResourceId"] = slices.Contains(cols, "resource_id")
     (*m)["includePolicyValueResourceTrunkTitle"] = slices.Contains(cols, "resource_trunk_title")
+    (*m)["includePolicyValueResourceAkas"] = slices.Contains(cols, "aka")
     (*m)["includePolicyValueTurbotResourceTypeId"] = slices.Contains(cols, "resource_type_id")
     (*m)["includePolicyValueState"] = slices.Contains(cols, "state")
     (*m)["includePolicyValueSecretValue"] = slices.Contains(cols, "secret_value")
@@ -105,6 +106,14 @@ func policyValueHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryDa
     return policyValue.Resource.Trunk.Title, nil
 }
 
+func policyValueHydrateResourceAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    policyValue, err := extractPolicyValueFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return policyValue.Resource.Turbot.Akas, nil
+}
+
 func policyValueHydrateResourceTypeId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
     policyValue, err := extractPolicyValueFromHydrateItem(h)
     if err != nil {
@@ -634,3 +643,139 @@ func policySettingHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *
     }
     return policySetting.Turbot.VersionID, nil
 }
+
+func extractPolicyValueExplainFromHydrateItem(h *plugin.HydrateData) (PolicyValueExplain, error) {
+    if explain, ok := h.Item.(PolicyValueExplain); ok {
+        return explain, nil
+    } else {
+        return PolicyValueExplain{}, fmt.Errorf("unable to parse hydrate item %v as a PolicyValueExplain", h.Item)
+    }
+}
+
+func policyValueExplainHydrateDepth(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Depth, nil
+}
+
+func policyValueExplainHydrateViaSmartFolder(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.ViaSmartFolder, nil
+}
+
+func policyValueExplainHydrateSettingId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Turbot.ID, nil
+}
+
+func policyValueExplainHydrateSettingResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Turbot.ResourceID, nil
+}
+
+func policyValueExplainHydrateSettingResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Resource.Trunk.Title, nil
+}
+
+func policyValueExplainHydratePrecedence(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Precedence, nil
+}
+
+func policyValueExplainHydrateIsEffective(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.IsEffective, nil
+}
+
+func policyValueExplainHydrateException(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Exception, nil
+}
+
+func policyValueExplainHydrateOrphan(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Orphan, nil
+}
+
+func policyValueExplainHydrateValue(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Value, nil
+}
+
+func policyValueExplainHydrateIsCalculated(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.IsCalculated, nil
+}
+
+func policyValueExplainHydratePolicyTypeTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.Type.Trunk.Title, nil
+}
+
+func policyValueExplainHydrateResolvedPrecedence(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Resolved.Precedence, nil
+}
+
+func policyValueExplainHydrateResolvedValue(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Resolved.Value, nil
+}
+
+func policyValueExplainHydrateValidFromTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.ValidFromTimestamp, nil
+}
+
+func policyValueExplainHydrateValidToTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    explain, err := extractPolicyValueExplainFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return explain.Setting.ValidToTimestamp, nil
+}
@@ -0,0 +1,343 @@
+package turbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-guardrails/errors"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	// maxResourceBatchSize bounds the number of ids requested in one batch
+	maxResourceBatchSize = 100
+	// maxResourceHints bounds the resource ids remembered per connection
+	maxResourceHints = 10000
+	// maxPrefetchedResources bounds the batch results kept for later lookups
+	maxPrefetchedResources = 5000
+	// resourceHintTTL is how long hinted ids and batch results are kept. They
+	// are only meant for the rest of the query that produced them.
+	resourceHintTTL = time.Minute
+)
+
+// resourceBatch is a set of resource ids fetched with a single request. The
+// request is made with the query data and variables of the first lookup in the
+// batch, lookups only share a batch if they select the same columns.
+type resourceBatch struct {
+	ctx       context.Context
+	d         *plugin.QueryData
+	variables map[string]interface{}
+	ids       []string
+	done      chan struct{}
+	results   map[string]*Resource
+	err       error
+}
+
+// resourceBatchQueue holds the batches waiting for the request in flight for
+// one connection and set of selected columns
+type resourceBatchQueue struct {
+	busy    bool
+	pending []*resourceBatch
+}
+
+// resourceHints are the resource ids streamed by other tables in the order
+// they were streamed, e.g. the resource_id of each control
+type resourceHints struct {
+	order  []string
+	hinted map[string]time.Time
+}
+
+type prefetchedResource struct {
+	resource *Resource
+	expires  time.Time
+}
+
+// resourceBatcher merges lookups of single resources by id into requests with
+// a resourceId:1,2,3 filter.
+//
+// Concurrent lookups, like those made when Steampipe splits an id in (...)
+// list into one call per value, share requests. The first lookup is sent
+// straight away, lookups made while it is in flight are queued and sent
+// together when it returns.
+//
+// The rescans of a nested loop join, e.g. a join on r.id = c.resource_id, are
+// sequential, so they are batched using hints instead. Tables with a
+// resource_id column record the ids they stream. A lookup of a hinted id also
+// fetches the hinted ids after it, and the results are kept briefly so the
+// following rescans are answered without a request.
+type resourceBatcher struct {
+	mu         sync.Mutex
+	queues     map[string]*resourceBatchQueue
+	hints      map[string]*resourceHints
+	prefetched map[string]prefetchedResource
+}
+
+var resourceLoader = &resourceBatcher{
+	queues:     map[string]*resourceBatchQueue{},
+	hints:      map[string]*resourceHints{},
+	prefetched: map[string]prefetchedResource{},
+}
+
+// hintResourceIds records resource ids streamed by a table, so the rescans of
+// a join on them can be batched. Ids are only recorded when the resource_id
+// column is selected.
+func hintResourceIds(d *plugin.QueryData, ids []string) {
+	if !slices.Contains(d.QueryContext.Columns, "resource_id") {
+		return
+	}
+	b := resourceLoader
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hints := b.hints[d.Connection.Name]
+	if hints == nil {
+		hints = &resourceHints{hinted: map[string]time.Time{}}
+		b.hints[d.Connection.Name] = hints
+	}
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if _, ok := hints.hinted[id]; ok {
+			continue
+		}
+		if len(hints.hinted) >= maxResourceHints {
+			hints.expire(now)
+			if len(hints.hinted) >= maxResourceHints {
+				return
+			}
+		}
+		hints.hinted[id] = now
+		hints.order = append(hints.order, id)
+	}
+}
+
+// take removes id from the hints, and if it was hinted also removes and
+// returns up to n of the oldest other hinted ids
+func (h *resourceHints) take(id string, n int, now time.Time) []string {
+	if _, ok := h.hinted[id]; !ok {
+		return nil
+	}
+	delete(h.hinted, id)
+	h.expire(now)
+
+	taken := []string{}
+	remaining := []string{}
+	for _, hinted := range h.order {
+		if _, ok := h.hinted[hinted]; !ok {
+			continue
+		}
+		if len(taken) < n {
+			taken = append(taken, hinted)
+			delete(h.hinted, hinted)
+			continue
+		}
+		remaining = append(remaining, hinted)
+	}
+	h.order = remaining
+	return taken
+}
+
+// expire drops the hints older than resourceHintTTL
+func (h *resourceHints) expire(now time.Time) {
+	remaining := []string{}
+	for _, id := range h.order {
+		added, ok := h.hinted[id]
+		if !ok {
+			continue
+		}
+		if now.Sub(added) > resourceHintTTL {
+			delete(h.hinted, id)
+			continue
+		}
+		remaining = append(remaining, id)
+	}
+	h.order = remaining
+}
+
+// getResourceBatched returns the resource with the given id, or nil if it
+// doesn't exist. The variables hold the includes for the selected columns.
+func getResourceBatched(ctx context.Context, d *plugin.QueryData, id string, variables map[string]interface{}) (*Resource, error) {
+	// Lookups can only share a request if they select the same columns
+	includes := map[string]interface{}{}
+	for k, v := range variables {
+		if strings.HasPrefix(k, "include") {
+			includes[k] = v
+		}
+	}
+	includesKey, err := json.Marshal(includes)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s-%s", d.Connection.Name, includesKey)
+
+	b := resourceLoader
+	now := time.Now()
+	b.mu.Lock()
+	if p, ok := b.prefetched[key+"/"+id]; ok && now.Before(p.expires) {
+		b.mu.Unlock()
+		return p.resource, nil
+	}
+
+	// The hinted ids after this one are the likely next lookups of a join
+	var extra []string
+	if hints := b.hints[d.Connection.Name]; hints != nil {
+		extra = hints.take(id, maxResourceBatchSize-1, now)
+	}
+
+	queue := b.queues[key]
+	if queue == nil {
+		queue = &resourceBatchQueue{}
+		b.queues[key] = queue
+	}
+	var batch *resourceBatch
+	if !queue.busy {
+		queue.busy = true
+		batch = newResourceBatch(ctx, d, variables, append([]string{id}, extra...))
+		b.mu.Unlock()
+		b.run(key, batch)
+	} else {
+		if n := len(queue.pending); n > 0 && len(queue.pending[n-1].ids) < maxResourceBatchSize {
+			batch = queue.pending[n-1]
+			batch.ids = append(batch.ids, id)
+			// Hinted ids that don't fit are dropped, their own lookups fetch
+			// them later
+			for _, hinted := range extra {
+				if len(batch.ids) >= maxResourceBatchSize {
+					break
+				}
+				batch.ids = append(batch.ids, hinted)
+			}
+		} else {
+			// The queued batch runs after the request in flight returns, so it
+			// must not be cancelled with this query
+			batch = newResourceBatch(context.WithoutCancel(ctx), d, variables, append([]string{id}, extra...))
+			queue.pending = append(queue.pending, batch)
+		}
+		b.mu.Unlock()
+	}
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	return batch.results[id], nil
+}
+
+func newResourceBatch(ctx context.Context, d *plugin.QueryData, variables map[string]interface{}, ids []string) *resourceBatch {
+	return &resourceBatch{ctx: ctx, d: d, variables: variables, ids: ids, done: make(chan struct{})}
+}
+
+// run fetches the batch, keeps the results for later lookups, then starts the
+// next batch in the queue, if any
+func (b *resourceBatcher) run(key string, batch *resourceBatch) {
+	// Ids are only added to batches while they are queued, so they are fixed
+	// once the batch runs
+	batch.results, batch.err = fetchResourcesById(batch.ctx, batch.d, batch.ids, batch.variables)
+	close(batch.done)
+
+	b.mu.Lock()
+	if batch.err == nil && len(batch.ids) > 1 {
+		b.keep(key, batch.ids, batch.results)
+	}
+	queue := b.queues[key]
+	if len(queue.pending) == 0 {
+		queue.busy = false
+		b.mu.Unlock()
+		return
+	}
+	next := queue.pending[0]
+	queue.pending = queue.pending[1:]
+	b.mu.Unlock()
+
+	go b.run(key, next)
+}
+
+// keep stores batch results for later lookups, including the ids that don't
+// exist, so rescans of those don't make a request either. Called with the
+// lock held.
+func (b *resourceBatcher) keep(key string, ids []string, results map[string]*Resource) {
+	now := time.Now()
+	if len(b.prefetched)+len(ids) > maxPrefetchedResources {
+		for k, p := range b.prefetched {
+			if now.After(p.expires) {
+				delete(b.prefetched, k)
+			}
+		}
+	}
+	for _, id := range ids {
+		if len(b.prefetched) >= maxPrefetchedResources {
+			return
+		}
+		b.prefetched[key+"/"+id] = prefetchedResource{resource: results[id], expires: now.Add(resourceHintTTL)}
+	}
+}
+
+// fetchResourcesById fetches the resources with resource(id:) for a single id,
+// or a resourceId filter for many. Ids that don't exist are missing from the
+// results.
+func fetchResourcesById(ctx context.Context, d *plugin.QueryData, ids []string, variables map[string]interface{}) (map[string]*Resource, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	requestVariables := map[string]interface{}{}
+	for k, v := range variables {
+		requestVariables[k] = v
+	}
+	results := map[string]*Resource{}
+
+	if len(ids) == 1 {
+		requestVariables["id"] = ids[0]
+		result := &ResourceResponse{}
+		err = conn.DoRequest(queryResourceGet, requestVariables, result)
+		if err != nil {
+			if errors.NotFoundError(err) {
+				return results, nil
+			}
+			return nil, err
+		}
+		results[ids[0]] = &result.Resource
+		return results, nil
+	}
+
+	// The id is needed to match the results to the lookups
+	requestVariables["includeResourceId"] = true
+	requestVariables["filter"] = []string{fmt.Sprintf("resourceId:%s level:self limit:%d", strings.Join(ids, ","), len(ids))}
+	requestVariables["next_token"] = ""
+
+	plugin.Logger(ctx).Debug("guardrails_resource.fetchResourcesById", "ids", len(ids))
+
+	result := &ResourcesResponse{}
+	err = conn.DoRequest(queryResourceList, requestVariables, result)
+	if err != nil {
+		if !errors.NotFoundError(err) {
+			return nil, err
+		}
+		// A resource was deleted while the batch was fetched, fall back to
+		// fetching the ids one by one
+		for _, id := range ids {
+			r, err := fetchResourcesById(ctx, d, []string{id}, variables)
+			if err != nil {
+				return nil, err
+			}
+			results[id] = r[id]
+		}
+		return results, nil
+	}
+	for i := range result.Resources.Items {
+		r := &result.Resources.Items[i]
+		results[r.Turbot.ID] = r
+	}
+	return results, nil
+}
@@ -159,7 +159,7 @@ func appendResourceColumnIncludes(m *map[string]interface{}, cols []string) {
     (*m)["includeResourceTitle"] = slices.Contains(cols, "title")
     (*m)["includeResourceTrunkTitle"] = slices.Contains(cols, "trunk_title")
     (*m)["includeResourceTags"] = slices.Contains(cols, "tags")
-    (*m)["includeResourceAkas"] = slices.Contains(cols, "akas")
+    (*m)["includeResourceAkas"] = slices.Contains(cols, "akas") || slices.Contains(cols, "aka")
     (*m)["includeResourceCreateTimestamp"] = slices.Contains(cols, "create_timestamp")
     (*m)["includeResourceData"] = slices.Contains(cols, "data")
     (*m)["includeResourceObject"] = slices.Contains(cols, "object")
@@ -309,3 +309,99 @@ func resourceHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *plugi
     }
     return resource.Turbot.VersionID, nil
 }
+
+func extractResourceHierarchyItemFromHydrateItem(h *plugin.HydrateData) (ResourceHierarchyItem, error) {
+    if item, ok := h.Item.(ResourceHierarchyItem); ok {
+        return item, nil
+    } else {
+        return ResourceHierarchyItem{}, fmt.Errorf("unable to parse hydrate item %v as a ResourceHierarchyItem", h.Item)
+    }
+}
+
+func resourceHierarchyHydrateDepth(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    item, err := extractResourceHierarchyItemFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return item.Depth, nil
+}
+
+func resourceHierarchyHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    item, err := extractResourceHierarchyItemFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return item.Resource.Turbot.ID, nil
+}
+
+func resourceHierarchyHydrateTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    item, err := extractResourceHierarchyItemFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return item.Resource.Turbot.Title, nil
+}
+
+func resourceHierarchyHydrateTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    item, err := extractResourceHierarchyItemFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return item.Resource.Trunk.Title, nil
+}
+
+func resourceHierarchyHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    item, err := extractResourceHierarchyItemFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return item.Resource.Type.URI, nil
+}
+
+func resourceHierarchyHydrateParentId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    item, err := extractResourceHierarchyItemFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return item.Resource.Turbot.ParentID, nil
+}
+
+func extractResourceSummaryFromHydrateItem(h *plugin.HydrateData) (ResourceSummary, error) {
+    if summary, ok := h.Item.(ResourceSummary); ok {
+        return summary, nil
+    } else {
+        return ResourceSummary{}, fmt.Errorf("unable to parse hydrate item %v as a ResourceSummary", h.Item)
+    }
+}
+
+func resourceSummaryHydrateResourceTypeId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Type.Turbot.ID, nil
+}
+
+func resourceSummaryHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Type.URI, nil
+}
+
+func resourceSummaryHydrateResourceTypeTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Type.Trunk.Title, nil
+}
+
+func resourceSummaryHydrateCount(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    summary, err := extractResourceSummaryFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return summary.Summary.Resource.Total, nil
+}
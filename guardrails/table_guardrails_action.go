@@ -0,0 +1,189 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsAction(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_action",
+		Description: "Actions run in the Turbot Guardrails workspace, from the activity feed.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "id", Require: plugin.Optional},
+				{Name: "action_type_uri", Require: plugin.Optional},
+				{Name: "actor_identity_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_type_uri", Require: plugin.Optional},
+				{Name: "create_timestamp", Require: plugin.Optional, Operators: []string{">", ">=", "=", "<", "<="}},
+				{Name: "filter", Require: plugin.Optional},
+			},
+			Hydrate: listAction,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the action notification.", Hydrate: actionHydrateId},
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the action was run.", Hydrate: actionHydrateCreateTimestamp},
+			{Name: "action_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the action type.", Hydrate: actionHydrateActionTypeTrunkTitle},
+			{Name: "actor_identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the identity that ran the action.", Hydrate: actionHydrateActorIdentityTrunkTitle},
+			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource the action was run on.", Hydrate: actionHydrateResourceTrunkTitle},
+			{Name: "state", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Outcome of the action.", Hydrate: actionHydrateState},
+			{Name: "reason", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Reason for the outcome of the action.", Hydrate: actionHydrateReason},
+			// Other columns
+			{Name: "action_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the action type.", Hydrate: actionHydrateActionTypeUri},
+			{Name: "actor_identity_id", Type: proto.ColumnType_INT, Transform: transform.FromValue().NullIfZero(), Description: "ID of the identity that ran the action.", Hydrate: actionHydrateActorIdentityId},
+			{Name: "control_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the control the action was run from, if any.", Hydrate: actionHydrateControlId},
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used for this action list."},
+			{Name: "message", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Message for the action notification.", Hydrate: actionHydrateMessage},
+			{Name: "process_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the process that ran the action.", Hydrate: actionHydrateProcessId},
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource the action was run on.", Hydrate: actionHydrateResourceId},
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the resource the action was run on.", Hydrate: actionHydrateResourceTypeUri},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryActionList = `
+query actionList($filter: [String!], $next_token: String) {
+  notifications(filter: $filter, paging: $next_token) {
+    items {
+      message
+      actor {
+        identity {
+          trunk {
+            title
+          }
+          turbot {
+            id
+          }
+        }
+      }
+      action {
+        state
+        reason
+        type {
+          uri
+          trunk {
+            title
+          }
+        }
+      }
+      resource {
+        trunk {
+          title
+        }
+        type {
+          uri
+        }
+      }
+      turbot {
+        id
+        createTimestamp
+        controlId
+        processId
+        resourceId
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listAction(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_action.listAction", "connection_error", err)
+		return nil, err
+	}
+
+	// Actions are recorded as action notifications
+	filters := []string{"notificationType:action"}
+	quals := d.EqualsQuals
+
+	filter := ""
+	if quals["filter"] != nil {
+		filter = quals["filter"].GetStringValue()
+		filters = append(filters, filter)
+	}
+
+	// Additional filters
+	if quals["id"] != nil {
+		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "id", "int64")))
+	}
+	if quals["action_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("actionTypeId:%s actionTypeLevel:self", getQualListValues(ctx, quals, "action_type_uri", "string")))
+	}
+	if quals["actor_identity_id"] != nil {
+		filters = append(filters, fmt.Sprintf("actorIdentityId:%s", getQualListValues(ctx, quals, "actor_identity_id", "int64")))
+	}
+	if quals["resource_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s", getQualListValues(ctx, quals, "resource_id", "int64")))
+	}
+	if quals["resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+	}
+	filters = appendTimestampFilters(d.Quals, "create_timestamp", "createTimestamp", filters)
+
+	// Default to a very large page size. Page sizes earlier in the filter string
+	// win, so this is only used as a fallback.
+	pageResults := false
+	// Add a limit if they haven't given one in the filter field
+	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
+	if !re.MatchString(filter) {
+		// The caller did not specify a limit, so set a high limit and page all
+		// results.
+		pageResults = true
+		var pageLimit int64 = 5000
+
+		// Adjust page limit, if less than default value
+		limit := d.QueryContext.Limit
+		if d.QueryContext.Limit != nil {
+			if *limit < pageLimit {
+				pageLimit = *limit
+			}
+		}
+		filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_action.listAction", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_action.listAction", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &ActionsResponse{}
+		err = conn.DoRequest(queryActionList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_action.listAction", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.Notifications.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if !pageResults || result.Notifications.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Notifications.Paging.Next
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,169 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsActionType(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_action_type",
+		Description: "Action types define the quick actions that can be run on resources in Turbot Guardrails.",
+		List: &plugin.ListConfig{
+			Hydrate: listActionType,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "uri", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getActionType,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the action type.", Hydrate: actionTypeHydrateId},
+			{Name: "uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the action type.", Hydrate: actionTypeHydrateUri},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title of the action type.", Hydrate: actionTypeHydrateTitle},
+			{Name: "trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title with full path of the action type.", Hydrate: actionTypeHydrateTrunkTitle},
+			{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Description of the action type.", Hydrate: actionTypeHydrateDescription},
+			{Name: "targets", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "URIs of the resource types the action can be run on.", Hydrate: actionTypeHydrateTargets},
+			{Name: "permission_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the permission type required to run the action.", Hydrate: actionTypeHydratePermissionTypeUri},
+			{Name: "permission_level_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the permission level required to run the action.", Hydrate: actionTypeHydratePermissionLevelUri},
+			// Other columns
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "AKA (also known as) identifiers for the action type.", Hydrate: actionTypeHydrateAkas},
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the action type was first discovered by Turbot. (It may have been created earlier.)", Hydrate: actionTypeHydrateCreateTimestamp},
+			{Name: "icon", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Icon of the action type.", Hydrate: actionTypeHydrateIcon},
+			{Name: "mod_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the mod that contains the action type.", Hydrate: actionTypeHydrateModUri},
+			{Name: "parent_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "ID for the parent of this action type.", Hydrate: actionTypeHydrateParentId},
+			{Name: "path", Type: proto.ColumnType_JSON, Transform: transform.FromValue().Transform(pathToArray), Description: "Hierarchy path with all identifiers of ancestors of the action type.", Hydrate: actionTypeHydratePath},
+			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the action type was last updated in Turbot.", Hydrate: actionTypeHydrateUpdateTimestamp},
+			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier for this version of the action type.", Hydrate: actionTypeHydrateVersionId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	actionTypeFields = `
+      description
+      icon
+      modUri
+      permissionType: get(path: "permission.type")
+      permissionLevel: get(path: "permission.level")
+      targets
+      title
+      trunk {
+        title
+      }
+      turbot {
+        akas
+        createTimestamp
+        id
+        parentId
+        path
+        updateTimestamp
+        versionId
+      }
+      uri
+`
+
+	queryActionTypeList = `
+query actionTypeList($filter: [String!], $next_token: String) {
+  actionTypes(filter: $filter, paging: $next_token) {
+    items {` + actionTypeFields + `    }
+    paging {
+      next
+    }
+  }
+}
+`
+
+	queryActionTypeGet = `
+query actionTypeGet($id: ID!) {
+  actionType(id: $id) {` + actionTypeFields + `  }
+}
+`
+)
+
+func listActionType(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_action_type.listActionType", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	// Additional filters
+	if quals["uri"] != nil {
+		filters = append(filters, fmt.Sprintf("actionTypeId:%s actionTypeLevel:self", getQualListValues(ctx, quals, "uri", "string")))
+	}
+
+	// Setting a high limit and page all results
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+
+	// Setting page limit
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_action_type.listActionType", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_action_type.listActionType", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &ActionTypesResponse{}
+		err = conn.DoRequest(queryActionTypeList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_action_type.listActionType", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.ActionTypes.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.ActionTypes.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ActionTypes.Paging.Next
+	}
+
+	return nil, nil
+}
+
+func getActionType(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_action_type.getActionType", "connection_error", err)
+		return nil, err
+	}
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	result := &ActionTypeResponse{}
+	err = conn.DoRequest(queryActionTypeGet, map[string]interface{}{"id": id}, result)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_action_type.getActionType", "query_error", err)
+		return nil, err
+	}
+	return result.ActionType, nil
+}
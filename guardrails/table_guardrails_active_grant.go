@@ -16,10 +16,10 @@ func tableGuardrailsActiveGrant(ctx context.Context) *plugin.Table {
 		Name:        "guardrails_active_grant",
 		Description: "All active grants of resources by Turbot Guardrails.",
 		List: &plugin.ListConfig{
-			KeyColumns: []*plugin.KeyColumn{
+			KeyColumns: append([]*plugin.KeyColumn{
 				{Name: "grant_id", Require: plugin.Optional},
 				{Name: "filter", Require: plugin.Optional},
-			},
+			}, grantQualKeyColumns()...),
 			Hydrate: listActiveGrants,
 		},
 		Columns: []*plugin.Column{
@@ -31,7 +31,7 @@ func tableGuardrailsActiveGrant(ctx context.Context) *plugin.Table {
 			{Name: "identity_family_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Family name of the identity.", Hydrate: activeGrantHydrateIdentityFamilyName},
 			{Name: "identity_given_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Given name of the identity.", Hydrate: activeGrantHydrateIdentityGivenName},
 			{Name: "identity_last_login_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Last login timestamp.", Hydrate: activeGrantHydrateIdentityLastLoginTimestamp},
-			{Name: "identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Profile id of the identity.", Hydrate: activeGrantHydrateIdentityProfileId},
+			{Name: "identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Profile id of the identity.", Hydrate: activeGrantHydrateIdentityProfileId},
 			{Name: "identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the grant identity.", Hydrate: activeGrantHydrateIdentityTrunkTitle},
 			{Name: "level_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The title of the level.", Hydrate: activeGrantHydrateLevelTitle},
 			{Name: "level_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the level.", Hydrate: activeGrantHydrateLevelTrunkTitle},
@@ -138,6 +138,15 @@ func listActiveGrants(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	if quals["grant_id"] != nil {
 		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "grant_id", "int64")))
 	}
+	filters, ok, err := appendGrantQualFilters(ctx, conn, quals, filters)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_active_grants.listActiveGrants", "query_error", err)
+		return nil, err
+	}
+	// No identity matches the identity quals
+	if !ok {
+		return nil, nil
+	}
 
 	// Default to a very large page size. Page sizes earlier in the filter string
 	// win, so this is only used as a fallback.
@@ -166,6 +175,7 @@ func listActiveGrants(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	}
 
 	appendActiveGrantColumnIncludes(&variables, d.QueryContext.Columns)
+	excludeUnsupportedIncludes(ctx, d, &variables)
 
 	for {
 		result := &ActiveGrantInfo{}
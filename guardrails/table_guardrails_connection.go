@@ -0,0 +1,235 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-guardrails/apiClient"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsConnection(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_connection",
+		Description: "Health of the connection to the Turbot Guardrails workspace, including the credentials in use and any validation error.",
+		List: &plugin.ListConfig{
+			Hydrate: listConnection,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "workspace", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Specifies the workspace URL.", Hydrate: connectionHydrateWorkspace},
+			{Name: "is_valid", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if the plugin could authenticate to the workspace.", Hydrate: connectionHydrateIsValid},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Error creating or validating the connection, if any.", Hydrate: connectionHydrateError},
+			{Name: "credential_source", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Where the credentials were found: config, key_file, credential_process, env or profile.", Hydrate: connectionHydrateCredentialSource},
+			{Name: "identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the profile the access key belongs to.", Hydrate: connectionHydrateIdentityTrunkTitle},
+			{Name: "workspace_version", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Version of the Turbot Guardrails workspace.", Hydrate: connectionHydrateWorkspaceVersion},
+			{Name: "latency_ms", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Time in milliseconds taken by the API to answer the validation request.", Hydrate: connectionHydrateLatencyMs},
+			// Other columns
+			{Name: "identity_email", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Email of the profile the access key belongs to.", Hydrate: connectionHydrateIdentityEmail},
+			{Name: "identity_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the profile the access key belongs to.", Hydrate: connectionHydrateIdentityId},
+		},
+	}
+}
+
+type ConnectionStatus struct {
+	Workspace        string
+	CredentialSource string
+	Identity         *ConnectionIdentity
+	WorkspaceVersion string
+	LatencyMs        *int64
+	Error            string
+}
+
+const (
+	queryConnectionAccessKey = `
+query connectionAccessKey($filter: [String!]) {
+  resources(filter: $filter) {
+    items {
+      turbot {
+        parentId
+      }
+    }
+  }
+}
+`
+
+	queryConnectionIdentity = `
+query connectionIdentity($id: ID!) {
+  resource(id: $id) {
+    email: get(path: "email")
+    trunk {
+      title
+    }
+    turbot {
+      id
+    }
+  }
+}
+`
+)
+
+// listConnection returns a single row describing the connection. Errors are
+// reported in the error column rather than failing the query, so the table can
+// be used to diagnose connections that don't work.
+func listConnection(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	status := ConnectionStatus{}
+
+	client, resolved, err := createClient(GetConfig(d.Connection))
+	status.CredentialSource = resolved.Source
+	status.Workspace = strings.Split(resolved.Credentials.Workspace, "/api/")[0]
+	if err != nil {
+		status.Error = err.Error()
+		d.StreamListItem(ctx, status)
+		return nil, nil
+	}
+
+	start := time.Now()
+	err = client.Validate()
+	latency := time.Since(start).Milliseconds()
+	status.LatencyMs = &latency
+	if err != nil {
+		status.Error = err.Error()
+		d.StreamListItem(ctx, status)
+		return nil, nil
+	}
+
+	// The version and identity are informational, so failures are logged
+	// rather than reported as a connection error
+	version, err := client.GetTurbotWorkspaceVersion()
+	if err != nil {
+		plugin.Logger(ctx).Warn("guardrails_connection.listConnection", "workspace_version_error", err)
+	} else {
+		status.WorkspaceVersion = version.String()
+	}
+
+	identity, err := getConnectionIdentity(client, resolved.Credentials.AccessKey)
+	if err != nil {
+		plugin.Logger(ctx).Warn("guardrails_connection.listConnection", "identity_error", err)
+	}
+	status.Identity = identity
+
+	d.StreamListItem(ctx, status)
+	return nil, nil
+}
+
+// getConnectionIdentity returns the profile the access key belongs to
+func getConnectionIdentity(client *apiClient.Client, accessKey string) (*ConnectionIdentity, error) {
+	accessKeyResult := &ConnectionAccessKeyResponse{}
+	variables := map[string]interface{}{
+		"filter": []string{
+			"resourceTypeId:'tmod:@turbot/turbot-iam#/resource/types/accessKey' resourceTypeLevel:self",
+			fmt.Sprintf("$.accessKeyId:'%s'", escapeFilterString(accessKey)),
+			"limit:1",
+		},
+	}
+	err := client.DoRequest(queryConnectionAccessKey, variables, accessKeyResult)
+	if err != nil {
+		return nil, err
+	}
+	if len(accessKeyResult.Resources.Items) == 0 {
+		return nil, nil
+	}
+
+	identityResult := &ConnectionIdentityResponse{}
+	err = client.DoRequest(queryConnectionIdentity, map[string]interface{}{"id": accessKeyResult.Resources.Items[0].Turbot.ParentID}, identityResult)
+	if err != nil {
+		return nil, err
+	}
+	return &identityResult.Resource, nil
+}
+
+func extractConnectionStatusFromHydrateItem(h *plugin.HydrateData) (ConnectionStatus, error) {
+	if status, ok := h.Item.(ConnectionStatus); ok {
+		return status, nil
+	} else {
+		return ConnectionStatus{}, fmt.Errorf("unable to parse hydrate item %v as a ConnectionStatus", h.Item)
+	}
+}
+
+func connectionHydrateWorkspace(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	if status.Workspace == "" {
+		return nil, nil
+	}
+	return status.Workspace, nil
+}
+
+func connectionHydrateIsValid(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	return status.Error == "", nil
+}
+
+func connectionHydrateError(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	if status.Error == "" {
+		return nil, nil
+	}
+	return status.Error, nil
+}
+
+func connectionHydrateCredentialSource(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	if status.CredentialSource == "" {
+		return nil, nil
+	}
+	return status.CredentialSource, nil
+}
+
+func connectionHydrateWorkspaceVersion(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	if status.WorkspaceVersion == "" {
+		return nil, nil
+	}
+	return status.WorkspaceVersion, nil
+}
+
+func connectionHydrateLatencyMs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	return status.LatencyMs, nil
+}
+
+func connectionHydrateIdentityTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil || status.Identity == nil {
+		return nil, err
+	}
+	return status.Identity.Trunk.Title, nil
+}
+
+func connectionHydrateIdentityEmail(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil || status.Identity == nil {
+		return nil, err
+	}
+	return status.Identity.Email, nil
+}
+
+func connectionHydrateIdentityId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	status, err := extractConnectionStatusFromHydrateItem(h)
+	if err != nil || status.Identity == nil {
+		return nil, err
+	}
+	return status.Identity.Turbot.ID, nil
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 
+	"github.com/turbot/steampipe-plugin-guardrails/errors"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -18,6 +19,7 @@ func tableGuardrailsControl(ctx context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "id", Require: plugin.Optional},
+				{Name: "aka", Require: plugin.Optional},
 				{Name: "control_type_id", Require: plugin.Optional},
 				{Name: "control_type_uri", Require: plugin.Optional},
 				{Name: "resource_type_id", Require: plugin.Optional},
@@ -27,6 +29,10 @@ func tableGuardrailsControl(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: listControl,
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getControl,
+		},
 		Columns: []*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the control.", Hydrate: controlHydrateId},
@@ -38,6 +44,7 @@ func tableGuardrailsControl(ctx context.Context) *plugin.Table {
 			{Name: "control_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the control type.", Hydrate: controlHydrateControlTypeTrunkTitle},
 
 			// Other columns
+			{Name: "aka", Type: proto.ColumnType_STRING, Transform: transform.FromValue().Transform(matchedAka), Description: "AKA (also known as) identifier of the resource, e.g. an AWS ARN, used to look up the controls.", Hydrate: controlHydrateResourceAkas},
 			{Name: "control_type_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the control type for this control.", Hydrate: controlHydrateControlTypeId},
 			{Name: "control_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the control type for this control.", Hydrate: controlHydrateControlTypeUri},
 			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the control was first discovered by Turbot. (It may have been created earlier.)", Hydrate: controlHydrateCreateTimestamp},
@@ -54,7 +61,7 @@ func tableGuardrailsControl(ctx context.Context) *plugin.Table {
 
 const (
 	queryControlList = `
-	query controlList($filter: [String!], $next_token: String, $includeControlState: Boolean!, $includeControlReason: Boolean!, $includeControlDetails: Boolean!, $includeControlResourceTypeUri: Boolean!, $includeControlResourceTrunkTitle: Boolean!, $includeControlTypeUri: Boolean!, $includeControlTypeTrunkTitle: Boolean!, $includeControlId: Boolean!, $includeControlTimestamp: Boolean!, $includeControlCreateTimestamp: Boolean!, $includeControlUpdateTimestamp: Boolean!, $includeControlVersionId: Boolean!, $includeControlTypeId: Boolean!, $includeControlResourceId: Boolean!, $includeControlResourceTypeId: Boolean!) {
+	query controlList($filter: [String!], $next_token: String, $includeControlState: Boolean!, $includeControlReason: Boolean!, $includeControlDetails: Boolean!, $includeControlResourceTypeUri: Boolean!, $includeControlResourceTrunkTitle: Boolean!, $includeControlTypeUri: Boolean!, $includeControlTypeTrunkTitle: Boolean!, $includeControlId: Boolean!, $includeControlTimestamp: Boolean!, $includeControlCreateTimestamp: Boolean!, $includeControlUpdateTimestamp: Boolean!, $includeControlVersionId: Boolean!, $includeControlTypeId: Boolean!, $includeControlResourceId: Boolean!, $includeControlResourceTypeId: Boolean!, $includeControlResourceAkas: Boolean!) {
 	controls(filter: $filter, paging: $next_token) {
 		items {
 			state @include(if: $includeControlState)
@@ -67,6 +74,9 @@ const (
 				trunk {
 					title @include(if: $includeControlResourceTrunkTitle)
 				}
+				turbot {
+					akas @include(if: $includeControlResourceAkas)
+				}
 			}
 			type {
 				uri @include(if: $includeControlTypeUri)
@@ -90,6 +100,43 @@ const (
 		}
 	}
 }
+`
+
+	queryControlGet = `
+	query controlGet($id: ID!, $includeControlState: Boolean!, $includeControlReason: Boolean!, $includeControlDetails: Boolean!, $includeControlResourceTypeUri: Boolean!, $includeControlResourceTrunkTitle: Boolean!, $includeControlTypeUri: Boolean!, $includeControlTypeTrunkTitle: Boolean!, $includeControlId: Boolean!, $includeControlTimestamp: Boolean!, $includeControlCreateTimestamp: Boolean!, $includeControlUpdateTimestamp: Boolean!, $includeControlVersionId: Boolean!, $includeControlTypeId: Boolean!, $includeControlResourceId: Boolean!, $includeControlResourceTypeId: Boolean!, $includeControlResourceAkas: Boolean!) {
+	control(id: $id) {
+		state @include(if: $includeControlState)
+		reason @include(if: $includeControlReason)
+		details @include(if: $includeControlDetails)
+		resource {
+			type {
+				uri @include(if: $includeControlResourceTypeUri)
+			}
+			trunk {
+				title @include(if: $includeControlResourceTrunkTitle)
+			}
+			turbot {
+				akas @include(if: $includeControlResourceAkas)
+			}
+		}
+		type {
+			uri @include(if: $includeControlTypeUri)
+			trunk {
+				title @include(if: $includeControlTypeTrunkTitle)
+			}
+		}
+		turbot {
+			id @include(if: $includeControlId)
+			timestamp @include(if: $includeControlTimestamp)
+			createTimestamp @include(if: $includeControlCreateTimestamp)
+			updateTimestamp @include(if: $includeControlUpdateTimestamp)
+			versionId @include(if: $includeControlVersionId)
+			controlTypeId @include(if: $includeControlTypeId)
+			resourceId @include(if: $includeControlResourceId)
+			resourceTypeId @include(if: $includeControlResourceTypeId)
+		}
+	}
+}
 `
 )
 
@@ -109,10 +156,19 @@ func listControl(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		filters = append(filters, filter)
 	}
 
+	// Both select the resource, and two resourceId terms can't be combined in
+	// one filter
+	if quals["aka"] != nil && filterSelectsResource(filter) {
+		return nil, fmt.Errorf("aka and a resourceId filter cannot both be set, use one of them to select the resource")
+	}
+
 	// Additional filters
 	if quals["id"] != nil {
 		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "id", "int64")))
 	}
+	if quals["aka"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "aka", "string")))
+	}
 	if quals["control_type_id"] != nil {
 		filters = append(filters, fmt.Sprintf("controlTypeId:%s controlTypeLevel:self", getQualListValues(ctx, quals, "control_type_id", "int64")))
 	}
@@ -167,6 +223,13 @@ func listControl(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 			plugin.Logger(ctx).Error("guardrails_control.listControl", "query_error", err)
 			return nil, err
 		}
+		// Lets a join to guardrails_resource on resource_id batch its lookups
+		resourceIds := []string{}
+		for _, r := range result.Controls.Items {
+			resourceIds = append(resourceIds, r.Turbot.ResourceID)
+		}
+		hintResourceIds(d, resourceIds)
+
 		for _, r := range result.Controls.Items {
 			d.StreamListItem(ctx, r)
 
@@ -183,3 +246,28 @@ func listControl(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 
 	return nil, nil
 }
+
+func getControl(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control.getControl", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"id": d.EqualsQuals["id"].GetInt64Value(),
+	}
+
+	controlColumnIncludes(&variables, d.QueryContext.Columns)
+
+	result := &ControlResponse{}
+	err = conn.DoRequest(queryControlGet, variables, result)
+	if err != nil {
+		if errors.NotFoundError(err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("guardrails_control.getControl", "query_error", err)
+		return nil, err
+	}
+	return result.Control, nil
+}
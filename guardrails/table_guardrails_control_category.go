@@ -0,0 +1,212 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsControlCategory(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_control_category",
+		Description: "Control categories group control types in Turbot Guardrails.",
+		List: &plugin.ListConfig{
+			Hydrate: listControlCategory,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "uri", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getControlCategory,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the control category.", Hydrate: controlCategoryHydrateId},
+			{Name: "uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the control category.", Hydrate: controlCategoryHydrateUri},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title of the control category.", Hydrate: controlCategoryHydrateTitle},
+			{Name: "trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title with full path of the control category.", Hydrate: controlCategoryHydrateTrunkTitle},
+			{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Description of the control category.", Hydrate: controlCategoryHydrateDescription},
+			{Name: "control_type_count", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of control types in the control category.", Hydrate: getControlCategoryControlTypeCount},
+			// Other columns
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "AKA (also known as) identifiers for the control category.", Hydrate: controlCategoryHydrateAkas},
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the control category was first discovered by Turbot. (It may have been created earlier.)", Hydrate: controlCategoryHydrateCreateTimestamp},
+			{Name: "parent_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID for the parent of this control category.", Hydrate: controlCategoryHydrateParentId},
+			{Name: "path", Type: proto.ColumnType_JSON, Transform: transform.FromValue().Transform(pathToArray), Description: "Hierarchy path with all identifiers of ancestors of the control category.", Hydrate: controlCategoryHydratePath},
+			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the control category was last updated in Turbot.", Hydrate: controlCategoryHydrateUpdateTimestamp},
+			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier for this version of the control category.", Hydrate: controlCategoryHydrateVersionId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	controlCategoryFields = `
+      description
+      title
+      trunk {
+        title
+      }
+      turbot {
+        akas
+        createTimestamp
+        id
+        parentId
+        path
+        updateTimestamp
+        versionId
+      }
+      uri
+`
+
+	queryControlCategoryList = `
+query controlCategoryList($filter: [String!], $next_token: String) {
+  controlCategories(filter: $filter, paging: $next_token) {
+    items {` + controlCategoryFields + `    }
+    paging {
+      next
+    }
+  }
+}
+`
+
+	queryControlCategoryControlTypes = `
+query controlCategoryControlTypes($filter: [String!], $next_token: String) {
+  controlTypes(filter: $filter, paging: $next_token) {
+    items {
+      turbot {
+        id
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+
+	queryControlCategoryGet = `
+query controlCategoryGet($id: ID!) {
+  controlCategory(id: $id) {` + controlCategoryFields + `  }
+}
+`
+)
+
+func listControlCategory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control_category.listControlCategory", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	// Additional filters
+	if quals["uri"] != nil {
+		filters = append(filters, fmt.Sprintf("controlCategoryId:%s controlCategoryLevel:self", getQualListValues(ctx, quals, "uri", "string")))
+	}
+
+	// Setting a high limit and page all results
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+
+	// Setting page limit
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_control_category.listControlCategory", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_control_category.listControlCategory", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &ControlCategoriesResponse{}
+		err = conn.DoRequest(queryControlCategoryList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_control_category.listControlCategory", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.ControlCategories.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.ControlCategories.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ControlCategories.Paging.Next
+	}
+
+	return nil, nil
+}
+
+func getControlCategory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control_category.getControlCategory", "connection_error", err)
+		return nil, err
+	}
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	result := &ControlCategoryResponse{}
+	err = conn.DoRequest(queryControlCategoryGet, map[string]interface{}{"id": id}, result)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control_category.getControlCategory", "query_error", err)
+		return nil, err
+	}
+	return result.ControlCategory, nil
+}
+
+// getControlCategoryControlTypeCount counts the control types in the category,
+// only when the control_type_count column is requested
+func getControlCategoryControlTypeCount(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	category, err := extractControlCategoryFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control_category.getControlCategoryControlTypeCount", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"filter":     []string{fmt.Sprintf("controlCategory:'%s'", escapeFilterString(category.URI)), "limit:5000"},
+		"next_token": "",
+	}
+
+	count := 0
+	for {
+		result := &ControlTypesResponse{}
+		err = conn.DoRequest(queryControlCategoryControlTypes, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_control_category.getControlCategoryControlTypeCount", "query_error", err)
+			return nil, err
+		}
+		count += len(result.ControlTypes.Items)
+		if result.ControlTypes.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ControlTypes.Paging.Next
+	}
+
+	return count, nil
+}
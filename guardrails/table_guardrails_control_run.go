@@ -0,0 +1,193 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	controlRunActionRun            = "run"
+	controlRunActionRunQuickAction = "run_quick_action"
+
+	defaultMaxActions = 100
+)
+
+func tableGuardrailsControlRun(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_control_run",
+		Description: "Run controls, or quick actions on their resources, in the Turbot Guardrails workspace. Requires allow_actions in the connection config.",
+		List: &plugin.ListConfig{
+			KeyColumns: append(plugin.AnyColumn([]string{"control_id", "filter"}),
+				&plugin.KeyColumn{Name: "action", Require: plugin.Required},
+				&plugin.KeyColumn{Name: "quick_action_uri", Require: plugin.Optional},
+			),
+			Hydrate: listControlRun,
+		},
+		// Every query must trigger the action, not return the result of an
+		// earlier run
+		Cache: &plugin.TableCacheOptions{Enabled: false},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "control_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the control that was run. For run_quick_action, the first matching control on the resource.", Hydrate: controlRunHydrateControlId},
+			{Name: "action", Type: proto.ColumnType_STRING, Transform: transform.FromQual("action"), Description: "Action to take: run or run_quick_action."},
+			{Name: "process_id", Type: proto.ColumnType_INT, Transform: transform.FromValue().NullIfZero(), Description: "ID of the process created to run the action.", Hydrate: controlRunHydrateProcessId},
+			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource the control is for.", Hydrate: controlRunHydrateResourceTrunkTitle},
+			// Other columns
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used to select the controls to run."},
+			{Name: "quick_action_uri", Type: proto.ColumnType_STRING, Transform: transform.FromQual("quick_action_uri"), Description: "URI of the quick action to run, required for the run_quick_action action."},
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource the control is for.", Hydrate: controlRunHydrateResourceId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+type ControlRun struct {
+	Control   ControlRunTarget
+	ProcessID string
+}
+
+const (
+	queryControlRunTargets = `
+query controlRunTargets($filter: [String!], $next_token: String) {
+  controls(filter: $filter, paging: $next_token) {
+    items {
+      resource {
+        trunk {
+          title
+        }
+      }
+      turbot {
+        id
+        resourceId
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listControlRun(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+	if config.AllowActions == nil || !*config.AllowActions {
+		return nil, fmt.Errorf("guardrails_control_run is disabled, set allow_actions = true in the connection config to run controls")
+	}
+
+	quals := d.EqualsQuals
+	action := quals["action"].GetStringValue()
+	quickActionUri := quals["quick_action_uri"].GetStringValue()
+	switch action {
+	case controlRunActionRun:
+	case controlRunActionRunQuickAction:
+		if quickActionUri == "" {
+			return nil, fmt.Errorf("quick_action_uri must be set for the %s action", controlRunActionRunQuickAction)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported action %q, must be %s or %s", action, controlRunActionRun, controlRunActionRunQuickAction)
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control_run.listControlRun", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	if quals["filter"] != nil {
+		// An empty filter matches every control in the workspace
+		filter := quals["filter"].GetStringValue()
+		if strings.TrimSpace(filter) == "" {
+			return nil, fmt.Errorf("filter must not be empty")
+		}
+		filters = append(filters, filter)
+	}
+	if quals["control_id"] != nil {
+		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "control_id", "int64")))
+	}
+
+	// A limit in the query caps the number of controls that are run
+	var pageLimit int64 = 5000
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_control_run.listControlRun", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	// All the targets are found before anything is run, so the query fails
+	// without side effects if there are more than max_actions. Quick actions
+	// run once per resource, however many of its controls match.
+	targets := []ControlRunTarget{}
+	resourceIds := map[string]bool{}
+	for {
+		result := &ControlRunTargetsResponse{}
+		err = conn.DoRequest(queryControlRunTargets, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_control_run.listControlRun", "query_error", err)
+			return nil, err
+		}
+		for _, c := range result.Controls.Items {
+			if action == controlRunActionRunQuickAction {
+				if resourceIds[c.Turbot.ResourceID] {
+					continue
+				}
+				resourceIds[c.Turbot.ResourceID] = true
+			}
+			targets = append(targets, c)
+		}
+		if result.Controls.Paging.Next == "" || (limit != nil && int64(len(targets)) >= *limit) {
+			break
+		}
+		variables["next_token"] = result.Controls.Paging.Next
+	}
+	if limit != nil && int64(len(targets)) > *limit {
+		targets = targets[:*limit]
+	}
+
+	maxActions := defaultMaxActions
+	if config.MaxActions != nil {
+		maxActions = *config.MaxActions
+	}
+	if len(targets) > maxActions {
+		return nil, fmt.Errorf("the query matches %d actions, more than max_actions (%d). Nothing was run, narrow the control_id or filter, or raise max_actions in the connection config", len(targets), maxActions)
+	}
+
+	for _, c := range targets {
+		var processId string
+		if action == controlRunActionRun {
+			processId, err = conn.RunControl(c.Turbot.ID)
+		} else {
+			processId, err = conn.RunQuickAction(c.Turbot.ResourceID, quickActionUri)
+		}
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_control_run.listControlRun", "action_error", err)
+			return nil, err
+		}
+		plugin.Logger(ctx).Info("guardrails_control_run.listControlRun", "action", action, "control_id", c.Turbot.ID, "resource_id", c.Turbot.ResourceID, "process_id", processId)
+
+		d.StreamListItem(ctx, ControlRun{Control: c, ProcessID: processId})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
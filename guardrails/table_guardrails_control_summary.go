@@ -0,0 +1,156 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsControlSummary(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_control_summary",
+		Description: "Number of controls of each control type in each state, counted by Turbot Guardrails.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "control_type_id", Require: plugin.Optional},
+				{Name: "control_type_uri", Require: plugin.Optional},
+				{Name: "resource_type_id", Require: plugin.Optional},
+				{Name: "resource_type_uri", Require: plugin.Optional},
+				{Name: "state", Require: plugin.Optional},
+				{Name: "filter", Require: plugin.Optional},
+			},
+			Hydrate: listControlSummary,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "control_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the control type.", Hydrate: controlSummaryHydrateControlTypeUri},
+			{Name: "control_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the control type.", Hydrate: controlSummaryHydrateControlTypeTrunkTitle},
+			{Name: "state", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "State of the controls: alarm, error, invalid, ok, skipped or tbd.", Hydrate: controlSummaryHydrateState},
+			{Name: "count", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of controls of the control type in the state.", Hydrate: controlSummaryHydrateCount},
+			// Other columns
+			{Name: "control_type_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the control type.", Hydrate: controlSummaryHydrateControlTypeId},
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used to select the controls to count."},
+			{Name: "resource_type_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("resource_type_id"), Description: "ID of the resource type the counted controls are for."},
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromQual("resource_type_uri"), Description: "URI of the resource type the counted controls are for."},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryControlSummaryList = `
+query controlSummaryList($filter: [String!], $next_token: String) {
+  controlSummariesByControlType(filter: $filter, paging: $next_token) {
+    items {
+      type {
+        uri
+        trunk {
+          title
+        }
+        turbot {
+          id
+        }
+      }
+      summary {
+        control {
+          alarm
+          error
+          invalid
+          ok
+          skipped
+          tbd
+          total
+        }
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listControlSummary(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control_summary.listControlSummary", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	filter := ""
+	if quals["filter"] != nil {
+		filter = quals["filter"].GetStringValue()
+		filters = append(filters, filter)
+	}
+
+	// Additional filters
+	if quals["control_type_id"] != nil {
+		filters = append(filters, fmt.Sprintf("controlTypeId:%s controlTypeLevel:self", getQualListValues(ctx, quals, "control_type_id", "int64")))
+	}
+	if quals["control_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("controlTypeId:%s controlTypeLevel:self", getQualListValues(ctx, quals, "control_type_uri", "string")))
+	}
+	if quals["resource_type_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_id", "int64")))
+	}
+	if quals["resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+	}
+	if quals["state"] != nil {
+		filters = append(filters, fmt.Sprintf("state:%s", getQualListValues(ctx, quals, "state", "string")))
+	}
+
+	// There is one item per control type, so page through all of them unless
+	// the caller gave a limit in the filter field
+	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
+	if !re.MatchString(filter) {
+		filters = append(filters, "limit:5000")
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_control_summary.listControlSummary", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_control_summary.listControlSummary", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &ControlSummariesResponse{}
+		err = conn.DoRequest(queryControlSummaryList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_control_summary.listControlSummary", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.ControlSummariesByControlType.Items {
+			// One row per state, leaving out states with no controls like a
+			// group by would
+			counts := controlStateCounts(r.Summary.Control)
+			for _, state := range controlStates {
+				if counts[state] == 0 {
+					continue
+				}
+				d.StreamListItem(ctx, ControlSummaryRow{Type: r.Type, State: state, Count: counts[state]})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+		if result.ControlSummariesByControlType.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ControlSummariesByControlType.Paging.Next
+	}
+
+	return nil, nil
+}
@@ -119,12 +119,6 @@ query controlTypeGet($id: ID!, $includeControlTypeCategoryId: Boolean!, $include
 )
 
 func listControlType(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	conn, err := connect(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("guardrails_control_type.listControlType", "connection_error", err)
-		return nil, err
-	}
-
 	filters := []string{}
 	quals := d.EqualsQuals
 
@@ -138,48 +132,32 @@ func listControlType(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	}
 
 	// Setting a high limit and page all results
-	var pageLimit int64 = 5000
-
-	// Adjust page limit, if less than default value
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < pageLimit {
-			pageLimit = *limit
-		}
-	}
-
-	// Setting page limit
+	pageLimit := getCataloguePageLimit(d)
 	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
 
 	plugin.Logger(ctx).Debug("guardrails_control_type.listControlType", "quals", quals)
 	plugin.Logger(ctx).Debug("guardrails_control_type.listControlType", "filters", filters)
 
 	variables := map[string]interface{}{
-		"filter":     filters,
-		"next_token": "",
+		"filter": filters,
 	}
 
 	appendControlTypeColumnIncludes(&variables, d.QueryContext.Columns)
 
-	for {
-		result := &ControlTypesResponse{}
-		err = conn.DoRequest(queryControlTypeList, variables, result)
-		if err != nil {
-			plugin.Logger(ctx).Error("guardrails_control_type.listControlType", "query_error", err)
-			return nil, err
-		}
+	err := listCatalogue[ControlTypesResponse](ctx, d, queryControlTypeList, variables, func(result *ControlTypesResponse) bool {
 		for _, r := range result.ControlTypes.Items {
 			d.StreamListItem(ctx, r)
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+				return false
 			}
 		}
-		if result.ControlTypes.Paging.Next == "" {
-			break
-		}
-		variables["next_token"] = result.ControlTypes.Paging.Next
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_control_type.listControlType", "query_error", err)
+		return nil, err
 	}
 
 	return nil, nil
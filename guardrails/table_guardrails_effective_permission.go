@@ -0,0 +1,327 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-guardrails/apiClient"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	groupProfileResourceTypeUri = "tmod:@turbot/turbot-iam#/resource/types/groupProfile"
+	profileResourceTypeUri      = "tmod:@turbot/turbot-iam#/resource/types/profile"
+)
+
+func tableGuardrailsEffectivePermission(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_effective_permission",
+		Description: "Effective permissions of users and groups on a resource, including grants inherited from ancestors.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "user_email", Require: plugin.Optional},
+			},
+			Hydrate: listEffectivePermission,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource the permissions are effective on.", Hydrate: effectivePermissionHydrateResourceId},
+			{Name: "user_email", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Email of the user with the permission.", Hydrate: effectivePermissionHydrateUserEmail},
+			{Name: "user_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Full title (including ancestor trunk) of the user profile.", Hydrate: effectivePermissionHydrateUserTrunkTitle},
+			{Name: "level_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The URI of the permission level.", Hydrate: effectivePermissionHydrateLevelUri},
+			{Name: "level_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The title of the permission level.", Hydrate: effectivePermissionHydrateLevelTitle},
+			{Name: "via_group", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title of the group profile the permission was granted to, or null if granted to a user directly.", Hydrate: effectivePermissionHydrateViaGroup},
+			{Name: "is_inherited", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if the permission was granted on an ancestor of the resource.", Hydrate: effectivePermissionHydrateIsInherited},
+			// Other columns
+			{Name: "grant_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the grant providing the permission.", Hydrate: effectivePermissionHydrateGrantId},
+			{Name: "grant_resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource the grant was made on.", Hydrate: effectivePermissionHydrateGrantResourceId},
+			{Name: "grant_resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource the grant was made on.", Hydrate: effectivePermissionHydrateGrantResourceTrunkTitle},
+			{Name: "user_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Profile id of the user.", Hydrate: effectivePermissionHydrateUserProfileId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+type EffectivePermission struct {
+	// Resource the permission is effective on
+	ResourceID string
+	Grant      EffectivePermissionGrant
+	// User holding the permission, either the grantee or a member of the group
+	User EffectivePermissionIdentity
+	// Group the grant was made to, nil when granted to the user directly
+	Group     *EffectivePermissionIdentity
+	Inherited bool
+}
+
+const (
+	queryEffectivePermissionPath = `
+query effectivePermissionPath($id: ID!) {
+  resource(id: $id) {
+    turbot {
+      path
+    }
+  }
+}
+`
+
+	queryEffectivePermissionGrants = `
+query effectivePermissionGrants($filter: [String!], $next_token: String, $includeEffectivePermissionUserProfileId: Boolean!) {
+  activeGrants(filter: $filter, paging: $next_token) {
+    items {
+      resource {
+        trunk {
+          title
+        }
+        turbot {
+          id
+        }
+      }
+      grant {
+        identity {
+          email: get(path: "email")
+          profileId: get(path: "profileId") @include(if: $includeEffectivePermissionUserProfileId)
+          trunk {
+            title
+          }
+          turbot {
+            id
+          }
+          type {
+            uri
+          }
+        }
+        level {
+          title
+          uri
+        }
+        turbot {
+          id
+        }
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+
+	queryEffectivePermissionProfiles = `
+query effectivePermissionProfiles($filter: [String!], $next_token: String, $includeEffectivePermissionUserProfileId: Boolean!) {
+  resources(filter: $filter, paging: $next_token) {
+    items {
+      email: get(path: "email")
+      profileId: get(path: "profileId") @include(if: $includeEffectivePermissionUserProfileId)
+      groupProfileIds: get(path: "groupProfileIds")
+      trunk {
+        title
+      }
+      turbot {
+        id
+      }
+      type {
+        uri
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listEffectivePermission(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_effective_permission.listEffectivePermission", "connection_error", err)
+		return nil, err
+	}
+
+	quals := d.EqualsQuals
+	filters := []string{}
+
+	// Without a resource, every active grant in the workspace is listed, as
+	// effective on the resource it was made on
+	var resourceId string
+	if quals["resource_id"] != nil {
+		resourceId = strconv.FormatInt(quals["resource_id"].GetInt64Value(), 10)
+
+		pathResult := &ResourcePathResponse{}
+		err = conn.DoRequest(queryEffectivePermissionPath, map[string]interface{}{"id": resourceId}, pathResult)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_effective_permission.listEffectivePermission", "query_error", err)
+			return nil, err
+		}
+
+		// A resource that doesn't exist has no path, so it has no permissions
+		path := pathResult.Resource.Turbot.Path
+		if path == "" {
+			return nil, nil
+		}
+
+		// Grants on the resource itself and on every ancestor in its path apply
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", strings.Join(strings.Split(path, "."), ",")))
+	}
+
+	variables := map[string]interface{}{
+		"next_token": "",
+		"includeEffectivePermissionUserProfileId": slices.Contains(d.QueryContext.Columns, "user_profile_id"),
+	}
+	excludeUnsupportedIncludes(ctx, d, &variables)
+	includeProfileId := variables["includeEffectivePermissionUserProfileId"].(bool)
+
+	// Users are matched by the profiles with the email. Their grants are the
+	// ones made to the profiles or to any group the profiles are members of.
+	var users []EffectivePermissionIdentity
+	if quals["user_email"] != nil {
+		users, err = listEffectivePermissionProfiles(conn, fmt.Sprintf("$.email:%s", getQualListValues(ctx, quals, "user_email", "string")), includeProfileId)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_effective_permission.listEffectivePermission", "query_error", err)
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, nil
+		}
+		identityIds := []string{}
+		for _, u := range users {
+			identityIds = append(identityIds, u.Turbot.ID)
+			for _, groupId := range u.groupProfileIds() {
+				if !slices.Contains(identityIds, groupId) {
+					identityIds = append(identityIds, groupId)
+				}
+			}
+		}
+		filters = append(filters, fmt.Sprintf("identityId:%s", strings.Join(identityIds, ",")))
+	}
+
+	// Setting a high limit and page all results
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_effective_permission.listEffectivePermission", "filters", filters)
+	variables["filter"] = filters
+
+	// Group members are looked up once per group, even if the group has
+	// several grants
+	groupMembers := map[string][]EffectivePermissionIdentity{}
+
+	for {
+		result := &EffectivePermissionGrantsResponse{}
+		err = conn.DoRequest(queryEffectivePermissionGrants, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_effective_permission.listEffectivePermission", "query_error", err)
+			return nil, err
+		}
+		for _, g := range result.ActiveGrants.Items {
+			identity := g.Grant.Identity
+			permission := EffectivePermission{ResourceID: resourceId, Grant: g}
+			if resourceId == "" {
+				permission.ResourceID = g.Resource.Turbot.ID
+			}
+			permission.Inherited = g.Resource.Turbot.ID != permission.ResourceID
+
+			if identity.Type.URI != groupProfileResourceTypeUri {
+				permission.User = identity
+				d.StreamListItem(ctx, permission)
+			} else {
+				// Grants to a group apply to each of its members
+				members, ok := groupMembers[identity.Turbot.ID]
+				if !ok {
+					if users != nil {
+						members = []EffectivePermissionIdentity{}
+						for _, u := range users {
+							if slices.Contains(u.groupProfileIds(), identity.Turbot.ID) {
+								members = append(members, u)
+							}
+						}
+					} else {
+						members, err = listEffectivePermissionProfiles(conn, fmt.Sprintf("$.groupProfileIds:%s", identity.Turbot.ID), includeProfileId)
+						if err != nil {
+							plugin.Logger(ctx).Error("guardrails_effective_permission.listEffectivePermission", "query_error", err)
+							return nil, err
+						}
+					}
+					groupMembers[identity.Turbot.ID] = members
+				}
+				for _, m := range members {
+					permission.User = m
+					permission.Group = &identity
+					d.StreamListItem(ctx, permission)
+
+					if d.RowsRemaining(ctx) == 0 {
+						return nil, nil
+					}
+				}
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.ActiveGrants.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ActiveGrants.Paging.Next
+	}
+
+	return nil, nil
+}
+
+// groupProfileIds returns the ids of the groups the user profile is a member of
+func (i EffectivePermissionIdentity) groupProfileIds() []string {
+	ids := []string{}
+	for _, id := range i.GroupProfileIDs {
+		switch v := id.(type) {
+		case string:
+			ids = append(ids, v)
+		case float64:
+			ids = append(ids, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+	return ids
+}
+
+// listEffectivePermissionProfiles returns the user profiles matching the filter
+func listEffectivePermissionProfiles(conn *apiClient.Client, filter string, includeProfileId bool) ([]EffectivePermissionIdentity, error) {
+	variables := map[string]interface{}{
+		"filter": []string{
+			fmt.Sprintf("resourceTypeId:'%s' resourceTypeLevel:self", profileResourceTypeUri),
+			filter,
+			"limit:5000",
+		},
+		"next_token": "",
+		"includeEffectivePermissionUserProfileId": includeProfileId,
+	}
+
+	profiles := []EffectivePermissionIdentity{}
+	for {
+		result := &EffectivePermissionProfilesResponse{}
+		err := conn.DoRequest(queryEffectivePermissionProfiles, variables, result)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, result.Resources.Items...)
+		if result.Resources.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Resources.Paging.Next
+	}
+
+	return profiles, nil
+}
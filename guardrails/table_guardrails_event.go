@@ -0,0 +1,158 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsEvent(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_event",
+		Description: "Real-time events received from cloud providers by the Turbot Guardrails workspace.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "id", Require: plugin.Optional},
+				{Name: "source", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_type_uri", Require: plugin.Optional},
+				{Name: "create_timestamp", Require: plugin.Optional, Operators: []string{">", ">=", "=", "<", "<="}},
+				{Name: "filter", Require: plugin.Optional},
+			},
+			Hydrate: listEvent,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the event.", Hydrate: eventHydrateId},
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the event was received.", Hydrate: eventHydrateCreateTimestamp},
+			{Name: "source", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Source of the event, e.g. aws.s3.", Hydrate: eventHydrateSource},
+			{Name: "event_type", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Type of the event, e.g. CreateBucket.", Hydrate: eventHydrateEventType},
+			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource the event is for.", Hydrate: eventHydrateResourceTrunkTitle},
+			// Other columns
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used for this event list."},
+			{Name: "process_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the process that handled the event.", Hydrate: eventHydrateProcessId},
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource the event is for.", Hydrate: eventHydrateResourceId},
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the resource the event is for.", Hydrate: eventHydrateResourceTypeUri},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryEventList = `
+query eventList($filter: [String!], $next_token: String) {
+  events(filter: $filter, paging: $next_token) {
+    items {
+      source
+      type
+      resource {
+        trunk {
+          title
+        }
+        type {
+          uri
+        }
+      }
+      turbot {
+        id
+        createTimestamp
+        processId
+        resourceId
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listEvent(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_event.listEvent", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	filter := ""
+	if quals["filter"] != nil {
+		filter = quals["filter"].GetStringValue()
+		filters = append(filters, filter)
+	}
+
+	// Additional filters
+	if quals["id"] != nil {
+		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "id", "int64")))
+	}
+	if quals["source"] != nil {
+		filters = append(filters, fmt.Sprintf("source:%s", getQualListValues(ctx, quals, "source", "string")))
+	}
+	if quals["resource_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s", getQualListValues(ctx, quals, "resource_id", "int64")))
+	}
+	if quals["resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+	}
+	filters = appendTimestampFilters(d.Quals, "create_timestamp", "createTimestamp", filters)
+
+	// Default to a very large page size. Page sizes earlier in the filter string
+	// win, so this is only used as a fallback.
+	pageResults := false
+	// Add a limit if they haven't given one in the filter field
+	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
+	if !re.MatchString(filter) {
+		// The caller did not specify a limit, so set a high limit and page all
+		// results.
+		pageResults = true
+		var pageLimit int64 = 5000
+
+		// Adjust page limit, if less than default value
+		limit := d.QueryContext.Limit
+		if d.QueryContext.Limit != nil {
+			if *limit < pageLimit {
+				pageLimit = *limit
+			}
+		}
+		filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_event.listEvent", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_event.listEvent", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &EventsResponse{}
+		err = conn.DoRequest(queryEventList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_event.listEvent", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.Events.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if !pageResults || result.Events.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Events.Paging.Next
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,163 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsFavorite(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_favorite",
+		Description: "Resources favorited by users in the Turbot Guardrails console.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "id", Require: plugin.Optional},
+				{Name: "identity_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_type_uri", Require: plugin.Optional},
+				{Name: "filter", Require: plugin.Optional},
+			},
+			Hydrate: listFavorite,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the favorite.", Hydrate: favoriteHydrateId},
+			{Name: "identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the identity that favorited the resource.", Hydrate: favoriteHydrateIdentityTrunkTitle},
+			{Name: "identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Profile id of the identity that favorited the resource.", Hydrate: favoriteHydrateIdentityProfileId},
+			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the favorited resource.", Hydrate: favoriteHydrateResourceTrunkTitle},
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the resource was favorited.", Hydrate: favoriteHydrateCreateTimestamp},
+			// Other columns
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used for this favorite list."},
+			{Name: "identity_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the identity that favorited the resource.", Hydrate: favoriteHydrateIdentityId},
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the favorited resource.", Hydrate: favoriteHydrateResourceId},
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the favorited resource.", Hydrate: favoriteHydrateResourceTypeUri},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryFavoriteList = `
+query favoriteList($filter: [String!], $next_token: String, $includeFavoriteIdentityProfileId: Boolean!) {
+  favorites(filter: $filter, paging: $next_token) {
+    items {
+      identity {
+        profileId: get(path: "profileId") @include(if: $includeFavoriteIdentityProfileId)
+        trunk {
+          title
+        }
+      }
+      resource {
+        trunk {
+          title
+        }
+        type {
+          uri
+        }
+      }
+      turbot {
+        id
+        createTimestamp
+        identityId
+        resourceId
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listFavorite(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_favorite.listFavorite", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	filter := ""
+	if quals["filter"] != nil {
+		filter = quals["filter"].GetStringValue()
+		filters = append(filters, filter)
+	}
+
+	// Additional filters
+	if quals["id"] != nil {
+		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "id", "int64")))
+	}
+	if quals["identity_id"] != nil {
+		filters = append(filters, fmt.Sprintf("identityId:%s", getQualListValues(ctx, quals, "identity_id", "int64")))
+	}
+	if quals["resource_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s", getQualListValues(ctx, quals, "resource_id", "int64")))
+	}
+	if quals["resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+	}
+
+	// Default to a very large page size. Page sizes earlier in the filter string
+	// win, so this is only used as a fallback.
+	pageResults := false
+	// Add a limit if they haven't given one in the filter field
+	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
+	if !re.MatchString(filter) {
+		// The caller did not specify a limit, so set a high limit and page all
+		// results.
+		pageResults = true
+		var pageLimit int64 = 5000
+
+		// Adjust page limit, if less than default value
+		limit := d.QueryContext.Limit
+		if d.QueryContext.Limit != nil {
+			if *limit < pageLimit {
+				pageLimit = *limit
+			}
+		}
+		filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_favorite.listFavorite", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_favorite.listFavorite", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":                           filters,
+		"next_token":                       "",
+		"includeFavoriteIdentityProfileId": slices.Contains(d.QueryContext.Columns, "identity_profile_id"),
+	}
+	excludeUnsupportedIncludes(ctx, d, &variables)
+
+	for {
+		result := &FavoritesResponse{}
+		err = conn.DoRequest(queryFavoriteList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_favorite.listFavorite", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.Favorites.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if !pageResults || result.Favorites.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Favorites.Paging.Next
+	}
+
+	return nil, nil
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/turbot/steampipe-plugin-guardrails/apiClient"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -16,43 +19,68 @@ func tableGuardrailsGrant(ctx context.Context) *plugin.Table {
 		Name:        "guardrails_grant",
 		Description: "All grants of resources by Turbot Guardrails.",
 		List: &plugin.ListConfig{
-			KeyColumns: []*plugin.KeyColumn{
+			KeyColumns: append([]*plugin.KeyColumn{
 				{Name: "id", Require: plugin.Optional},
 				{Name: "filter", Require: plugin.Optional},
-			},
+			}, grantQualKeyColumns()...),
 			Hydrate: listGrants,
 		},
-		Columns: []*plugin.Column{
-			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the grant.", Hydrate: grantHydrateGrantId},
-			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the resource.", Hydrate: grantHydrateResourceId},
-			{Name: "identity_status", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Status of the identity.", Hydrate: grantHydrateIdentityStatus},
-			{Name: "identity_display_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Display name of the identity.", Hydrate: grantHydrateIdentityDisplayName},
-			{Name: "identity_email", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Email identity for the identity.", Hydrate: grantHydrateIdentityEmail},
-			{Name: "identity_family_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Family name of the identity.", Hydrate: grantHydrateIdentityFamilyName},
-			{Name: "identity_given_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Given name of the identity.", Hydrate: grantHydrateIdentityGivenName},
-			{Name: "identity_last_login_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Last login timestamp.", Hydrate: grantHydrateIdentityLastLoginTimestamp},
-			{Name: "identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Profile id of the identity.", Hydrate: grantHydrateIdentityProfileId},
-			{Name: "identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the grant identity.", Hydrate: grantHydrateIdentityTrunkTitle},
-			{Name: "level_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The title of the level.", Hydrate: grantHydrateLevelTitle},
-			{Name: "level_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the level.", Hydrate: grantHydrateLevelTrunkTitle},
-			{Name: "level_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The URI of the level.", Hydrate: grantHydrateLevelUri},
-			{Name: "resource_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the grant type.", Hydrate: grantHydrateResourceTypeTrunkTitle},
-			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource.", Hydrate: grantHydrateResourceTrunkTitle},
-			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type.", Hydrate: grantHydrateResourceTypeUri},
-			{Name: "identity_akas", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "AKA (also known as) identifiers for the identity", Hydrate: grantHydrateIdentityAkas},
-			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue().NullIfEqual(""), Description: "The create time of the grant.", Hydrate: grantHydrateCreateTimestamp},
-			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used for this grant list."},
-			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue().NullIfEqual(""), Description: "Timestamp when the grant was last modified (created, updated or deleted).", Hydrate: grantHydrateTimestamp},
-			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the grant was last updated in Turbot.", Hydrate: grantHydrateUpdateTimestamp},
-			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue().NullIfEqual(""), Description: "Unique identifier for this version of the identity.", Hydrate: grantHydrateVersionId},
-			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
-		},
+		Columns: grantColumns(),
+	}
+}
+
+// grantColumns are shared by guardrails_grant and guardrails_grant_pending
+func grantColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the grant.", Hydrate: grantHydrateGrantId},
+		{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the resource.", Hydrate: grantHydrateResourceId},
+		{Name: "identity_status", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Status of the identity.", Hydrate: grantHydrateIdentityStatus},
+		{Name: "identity_display_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Display name of the identity.", Hydrate: grantHydrateIdentityDisplayName},
+		{Name: "identity_email", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Email identity for the identity.", Hydrate: grantHydrateIdentityEmail},
+		{Name: "identity_family_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Family name of the identity.", Hydrate: grantHydrateIdentityFamilyName},
+		{Name: "identity_given_name", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Given name of the identity.", Hydrate: grantHydrateIdentityGivenName},
+		{Name: "identity_last_login_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Last login timestamp.", Hydrate: grantHydrateIdentityLastLoginTimestamp},
+		{Name: "identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Profile id of the identity.", Hydrate: grantHydrateIdentityProfileId},
+		{Name: "identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the grant identity.", Hydrate: grantHydrateIdentityTrunkTitle},
+		{Name: "level_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The title of the level.", Hydrate: grantHydrateLevelTitle},
+		{Name: "level_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the level.", Hydrate: grantHydrateLevelTrunkTitle},
+		{Name: "level_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The URI of the level.", Hydrate: grantHydrateLevelUri},
+		{Name: "resource_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the grant type.", Hydrate: grantHydrateResourceTypeTrunkTitle},
+		{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource.", Hydrate: grantHydrateResourceTrunkTitle},
+		{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type.", Hydrate: grantHydrateResourceTypeUri},
+		{Name: "identity_akas", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "AKA (also known as) identifiers for the identity", Hydrate: grantHydrateIdentityAkas},
+		{Name: "valid_from_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the grant becomes valid.", Hydrate: grantHydrateValidFromTimestamp},
+		{Name: "valid_to_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the grant expires.", Hydrate: grantHydrateValidToTimestamp},
+		{Name: "expires_in_seconds", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Seconds left until the grant expires, negative if it has already expired. Null if the grant does not expire.", Hydrate: grantHydrateExpiresInSeconds},
+		{Name: "is_activated", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if the grant is activated on at least one resource.", Hydrate: grantHydrateIsActivated},
+		{Name: "active_grant_resource_ids", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "IDs of the resources the grant is activated on.", Hydrate: grantHydrateActiveGrantResourceIds},
+		{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue().NullIfEqual(""), Description: "The create time of the grant.", Hydrate: grantHydrateCreateTimestamp},
+		{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used for this grant list."},
+		{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue().NullIfEqual(""), Description: "Timestamp when the grant was last modified (created, updated or deleted).", Hydrate: grantHydrateTimestamp},
+		{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the grant was last updated in Turbot.", Hydrate: grantHydrateUpdateTimestamp},
+		{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue().NullIfEqual(""), Description: "Unique identifier for this version of the identity.", Hydrate: grantHydrateVersionId},
+		{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
 	}
 }
 
 const (
+	queryGrantIdentityIds = `
+query grantIdentityIds($filter: [String!], $next_token: String) {
+  resources(filter: $filter, paging: $next_token) {
+    items {
+      turbot {
+        id
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+
 	grants = `
-	query MyQuery($filter: [String!], $paging: String, $includeGrantResourceAkas: Boolean!, $includeGrantResourceTitle: Boolean!, $includeGrantResourceTrunkTitle: Boolean!, $includeGrantResourceTypeURI: Boolean!, $includeGrantResourceTypeTrunkTitle: Boolean!, $includeGrantResourceId: Boolean!, $includeGrantResourceCreateTimestamp: Boolean!, $includeGrantResourceDeleteTimestamp: Boolean!, $includeGrantResourceTimestamp: Boolean!, $includeGrantResourceVersionId: Boolean!, $includeGrantResourceUpdateTimestamp: Boolean!, $includeGrantIdentityAkas: Boolean!, $includeGrantIdentityEmail: Boolean!, $includeGrantIdentityStatus: Boolean!, $includeGrantIdentityGivenName: Boolean!, $includeGrantIdentityProfileId: Boolean!, $includeGrantIdentityFamilyName: Boolean!, $includeGrantIdentityDisplayName: Boolean!, $includeGrantIdentityLastLoginTimestamp: Boolean!, $includeGrantIdentityTrunkTitle: Boolean!, $includeGrantLevelTitle: Boolean!, $includeGrantLevelURI: Boolean!, $includeGrantLevelTrunkTitle: Boolean!, $includeGrantTurbotId: Boolean!, $includeGrantTurbotCreateTimestamp: Boolean!, $includeGrantTurbotDeleteTimestamp: Boolean!, $includeGrantTurbotTimestamp: Boolean!, $includeGrantTurbotVersionId: Boolean!, $includeGrantTurbotUpdateTimestamp: Boolean!) {
+	query MyQuery($filter: [String!], $paging: String, $includeGrantResourceAkas: Boolean!, $includeGrantResourceTitle: Boolean!, $includeGrantResourceTrunkTitle: Boolean!, $includeGrantResourceTypeURI: Boolean!, $includeGrantResourceTypeTrunkTitle: Boolean!, $includeGrantResourceId: Boolean!, $includeGrantResourceCreateTimestamp: Boolean!, $includeGrantResourceDeleteTimestamp: Boolean!, $includeGrantResourceTimestamp: Boolean!, $includeGrantResourceVersionId: Boolean!, $includeGrantResourceUpdateTimestamp: Boolean!, $includeGrantIdentityAkas: Boolean!, $includeGrantIdentityEmail: Boolean!, $includeGrantIdentityStatus: Boolean!, $includeGrantIdentityGivenName: Boolean!, $includeGrantIdentityProfileId: Boolean!, $includeGrantIdentityFamilyName: Boolean!, $includeGrantIdentityDisplayName: Boolean!, $includeGrantIdentityLastLoginTimestamp: Boolean!, $includeGrantIdentityTrunkTitle: Boolean!, $includeGrantLevelTitle: Boolean!, $includeGrantLevelURI: Boolean!, $includeGrantLevelTrunkTitle: Boolean!, $includeGrantTurbotId: Boolean!, $includeGrantTurbotCreateTimestamp: Boolean!, $includeGrantTurbotDeleteTimestamp: Boolean!, $includeGrantTurbotTimestamp: Boolean!, $includeGrantTurbotVersionId: Boolean!, $includeGrantTurbotUpdateTimestamp: Boolean!, $includeGrantValidFromTimestamp: Boolean!, $includeGrantValidToTimestamp: Boolean!) {
 		grants(filter: $filter, paging: $paging) {
 		  items {
 			resource {
@@ -104,16 +132,41 @@ const (
 			versionId @include(if: $includeGrantTurbotVersionId)
 			updateTimestamp @include(if: $includeGrantTurbotUpdateTimestamp)
 		}
+		validFromTimestamp @include(if: $includeGrantValidFromTimestamp)
+		validToTimestamp @include(if: $includeGrantValidToTimestamp)
 		}
 		paging {
 		next
 	}
 	}
 	}
+`
+
+	queryGrantActivations = `
+query grantActivations($filter: [String!], $next_token: String) {
+  activeGrants(filter: $filter, paging: $next_token) {
+    items {
+      turbot {
+        grantId
+        resourceId
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
 `
 )
 
 func listGrants(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return listGrantsByActivation(ctx, d, false)
+}
+
+// listGrantsByActivation streams grants, looking up where each grant is
+// activated when the activation columns are requested. If pendingOnly is set,
+// only grants that are not activated on any resource are streamed.
+func listGrantsByActivation(ctx context.Context, d *plugin.QueryData, pendingOnly bool) (interface{}, error) {
 	conn, err := connect(ctx, d)
 	if err != nil {
 		plugin.Logger(ctx).Error("guardrails_grants.listGrants", "connection_error", err)
@@ -133,10 +186,20 @@ func listGrants(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	if quals["id"] != nil {
 		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "id", "int64")))
 	}
+	filters, ok, err := appendGrantQualFilters(ctx, conn, quals, filters)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_grants.listGrants", "query_error", err)
+		return nil, err
+	}
+	// No identity matches the identity quals
+	if !ok {
+		return nil, nil
+	}
 
 	// Default to a very large page size. Page sizes earlier in the filter string
-	// win, so this is only used as a fallback.
-	pageResults := false
+	// win, so this is only used as a fallback. Pending grants are picked out
+	// client-side, so they are paged even when the filter has a limit.
+	pageResults := pendingOnly
 	// Add a limit if they haven't given one in the filter field
 	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
 	if !re.MatchString(filter) {
@@ -159,19 +222,47 @@ func listGrants(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	plugin.Logger(ctx).Debug("guardrails_grants.listGrants", "filters", filters)
 
 	variables := map[string]interface{}{
-		"filter":     filters,
-		"next_token": "",
+		"filter": filters,
+		"paging": "",
 	}
 
-	appendGrantColumnIncludes(&variables, d.QueryContext.Columns)
+	cols := d.QueryContext.Columns
+	withActivations := pendingOnly || slices.Contains(cols, "is_activated") || slices.Contains(cols, "active_grant_resource_ids")
+	if withActivations {
+		// Grant ids are needed to look up the activations
+		cols = append(slices.Clone(cols), "id")
+	}
+	appendGrantColumnIncludes(&variables, cols)
+	excludeUnsupportedIncludes(ctx, d, &variables)
 
 	for {
 		result := &GrantInfo{}
 		err = conn.DoRequest(grants, variables, result)
 		if err != nil {
 			plugin.Logger(ctx).Error("guardrails_grants.listGrants", "query_error", err)
+			return nil, err
 		}
+
+		var activations map[string][]string
+		if withActivations {
+			grantIds := []string{}
+			for _, grantDetails := range result.Grants.Items {
+				grantIds = append(grantIds, grantDetails.Turbot.ID)
+			}
+			activations, err = listGrantActivations(conn, grantIds)
+			if err != nil {
+				plugin.Logger(ctx).Error("guardrails_grants.listGrants", "query_error", err)
+				return nil, err
+			}
+		}
+
 		for _, grantDetails := range result.Grants.Items {
+			if withActivations {
+				grantDetails.ActiveGrantResourceIDs = activations[grantDetails.Turbot.ID]
+				if pendingOnly && len(grantDetails.ActiveGrantResourceIDs) > 0 {
+					continue
+				}
+			}
 
 			d.StreamListItem(ctx, grantDetails)
 			// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -183,8 +274,39 @@ func listGrants(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 			break
 		}
 
-		variables["next_token"] = result.Grants.Paging.Next
+		variables["paging"] = result.Grants.Paging.Next
+	}
+
+	return nil, nil
+}
+
+// listGrantActivations returns the ids of the resources each grant is
+// activated on, keyed by grant id
+func listGrantActivations(conn *apiClient.Client, grantIds []string) (map[string][]string, error) {
+	activations := map[string][]string{}
+	if len(grantIds) == 0 {
+		return activations, nil
+	}
+
+	variables := map[string]interface{}{
+		"filter":     []string{fmt.Sprintf("grantId:%s", strings.Join(grantIds, ",")), "limit:5000"},
+		"next_token": "",
+	}
+
+	for {
+		result := &GrantActivationsResponse{}
+		err := conn.DoRequest(queryGrantActivations, variables, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range result.ActiveGrants.Items {
+			activations[a.Turbot.GrantID] = append(activations[a.Turbot.GrantID], a.Turbot.ResourceID)
+		}
+		if result.ActiveGrants.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ActiveGrants.Paging.Next
 	}
 
-	return nil, err
+	return activations, nil
 }
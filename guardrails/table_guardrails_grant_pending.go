@@ -0,0 +1,26 @@
+package turbot
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func tableGuardrailsGrantPending(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_grant_pending",
+		Description: "Grants in Turbot Guardrails that are not activated on any resource.",
+		List: &plugin.ListConfig{
+			KeyColumns: append([]*plugin.KeyColumn{
+				{Name: "id", Require: plugin.Optional},
+				{Name: "filter", Require: plugin.Optional},
+			}, grantQualKeyColumns()...),
+			Hydrate: listGrantPending,
+		},
+		Columns: grantColumns(),
+	}
+}
+
+func listGrantPending(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return listGrantsByActivation(ctx, d, true)
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
-	"time"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -99,7 +98,7 @@ func tableGuardrailsNotification(ctx context.Context) *plugin.Table {
 			{Name: "active_grant_new_version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Active grant version ID of the grant after the notification.", Hydrate: notificationHydrateActiveGrantNewVersionId},
 			{Name: "active_grant_old_version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Version ID of the active grant before the event.", Hydrate: notificationHydrateActiveGrantOldVersionId},
 			{Name: "active_grant_valid_to_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Optional end date for the active grant to expire.", Hydrate: notificationHydrateActiveGrantValidToTimestamp},
-			{Name: "active_grant_identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The identity of profile id for this active grant.", Hydrate: notificationHydrateActiveGrantIdentityProfileId},
+			{Name: "active_grant_identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "The identity of profile id for this active grant.", Hydrate: notificationHydrateActiveGrantIdentityProfileId},
 			{Name: "active_grant_identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "This is the title of hierarchy from the root down to this identity (i.e. Identity whoes access got revoked/permiited) for this active grant.", Hydrate: notificationHydrateActiveGrantIdentityTrunkTitle},
 			{Name: "active_grant_level_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The name of the active grant level.", Hydrate: notificationHydrateActiveGrantLevelTitle},
 			{Name: "active_grant_permission_level_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "The unique identifier for the active grant permission level.", Hydrate: notificationHydrateActiveGrantPermissionLevelId},
@@ -112,7 +111,7 @@ func tableGuardrailsNotification(ctx context.Context) *plugin.Table {
 			{Name: "grant_new_version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Version ID of the grant after the event.", Hydrate: notificationHydrateTurbotGrantNewVersionId},
 			{Name: "grant_old_version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Version ID of the grant before the event.", Hydrate: notificationHydrateTurbotGrantOldVersionId},
 			{Name: "grant_valid_to_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Optional end date for the grant.", Hydrate: notificationHydrateTurbotGrantValidToTimestamp},
-			{Name: "grant_identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The identity profile id for this grant.", Hydrate: notificationHydrateTurbotGrantIdentityProfileId},
+			{Name: "grant_identity_profile_id", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "The identity profile id for this grant.", Hydrate: notificationHydrateTurbotGrantIdentityProfileId},
 			{Name: "grant_identity_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "This is the title of hierarchy from the root down to this identity (i.e. Identity whoes access got revoked/permiited) for this grant.", Hydrate: notificationHydrateTurbotGrantIdentityTrunkTitle},
 			{Name: "grant_level_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The name of the permission level.", Hydrate: notificationHydrateTurbotGrantLevelTitle},
 			{Name: "grant_permission_level_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "The unique identifier for the permission level.", Hydrate: notificationHydrateTurbotGrantPermissionLevelId},
@@ -444,19 +443,7 @@ func listNotification(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		filters = append(filters, fmt.Sprintf("policyTypeId:%s policyTypeLevel:self", getQualListValues(ctx, quals, "policy_type_uri", "string")))
 	}
 
-	if allQuals["create_timestamp"] != nil {
-		for _, q := range allQuals["create_timestamp"].Quals {
-			// Subtracted 1 minute to FilterFrom time and Added 1 minute to FilterTo time to miss any results due to time conersions in steampipe
-			switch q.Operator {
-			case "=":
-				filters = append(filters, fmt.Sprintf("createTimestamp:'%s'", q.Value.GetTimestampValue().AsTime().Format(filterTimeFormat)))
-			case ">=", ">":
-				filters = append(filters, fmt.Sprintf("createTimestamp:>='%s'", q.Value.GetTimestampValue().AsTime().Add(-1*time.Minute).Format(filterTimeFormat)))
-			case "<", "<=":
-				filters = append(filters, fmt.Sprintf("createTimestamp:<='%s'", q.Value.GetTimestampValue().AsTime().Add(1*time.Minute).Format(filterTimeFormat)))
-			}
-		}
-	}
+	filters = appendTimestampFilters(allQuals, "create_timestamp", "createTimestamp", filters)
 
 	// Default to a very large page size. Page sizes earlier in the filter string
 	// win, so this is only used as a fallback.
@@ -487,6 +474,7 @@ func listNotification(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 	}
 
 	appendNotificationColumnIncludes(&variables, d.QueryContext.Columns)
+	excludeUnsupportedIncludes(ctx, d, &variables)
 	for {
 		result := &NotificationsResponse{}
 		err = conn.DoRequest(queryNotificationList, variables, result)
@@ -525,6 +513,7 @@ func getNotification(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	}
 
 	appendNotificationColumnIncludes(&variables, d.QueryContext.Columns)
+	excludeUnsupportedIncludes(ctx, d, &variables)
 
 	result := &NotificationsGetResponse{}
 	err = conn.DoRequest(queryNotificationGet, variables, result)
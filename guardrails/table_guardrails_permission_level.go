@@ -0,0 +1,145 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsPermissionLevel(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_permission_level",
+		Description: "Permission levels, like User, Operator, Admin and Owner, that can be granted in Turbot Guardrails.",
+		List: &plugin.ListConfig{
+			Hydrate: listPermissionLevel,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "uri", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the permission level.", Hydrate: permissionHydrateId},
+			{Name: "uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the permission level.", Hydrate: permissionHydrateUri},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title of the permission level.", Hydrate: permissionHydrateTitle},
+			{Name: "trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title with full path of the permission level.", Hydrate: permissionHydrateTrunkTitle},
+			{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Description of the permission level.", Hydrate: permissionHydrateDescription},
+			{Name: "rank", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of permission levels below this level in the level hierarchy. Levels that include others, like Owner, have a higher rank than the levels they include.", Hydrate: permissionHydrateRank},
+			// Other columns
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "AKA (also known as) identifiers for the permission level.", Hydrate: permissionHydrateAkas},
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the permission level was first discovered by Turbot. (It may have been created earlier.)", Hydrate: permissionHydrateCreateTimestamp},
+			{Name: "mod_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the mod that contains the permission level.", Hydrate: permissionHydrateModUri},
+			{Name: "parent_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID for the parent of this permission level.", Hydrate: permissionHydrateParentId},
+			{Name: "path", Type: proto.ColumnType_JSON, Transform: transform.FromValue().Transform(pathToArray), Description: "Hierarchy path with all identifiers of ancestors of the permission level.", Hydrate: permissionHydratePath},
+			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the permission level was last updated in Turbot.", Hydrate: permissionHydrateUpdateTimestamp},
+			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier for this version of the permission level.", Hydrate: permissionHydrateVersionId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryPermissionLevelList = `
+query permissionLevelList($filter: [String!], $next_token: String) {
+  permissionLevels(filter: $filter, paging: $next_token) {
+    items {` + permissionFields + `    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listPermissionLevel(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_permission_level.listPermissionLevel", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	// Additional filters
+	if quals["uri"] != nil {
+		filters = append(filters, fmt.Sprintf("permissionLevelId:%s permissionLevelLevel:self", getQualListValues(ctx, quals, "uri", "string")))
+	}
+
+	// Setting a high limit and page all results
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+
+	// Setting page limit
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_permission_level.listPermissionLevel", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_permission_level.listPermissionLevel", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	// The rank depends on the other levels in the hierarchy, so all the levels
+	// are needed to work it out, whatever the quals
+	var ranks map[string]int
+	if slices.Contains(d.QueryContext.Columns, "rank") {
+		levels := []PermissionDefinition{}
+		rankVariables := map[string]interface{}{
+			"filter":     []string{"limit:5000"},
+			"next_token": "",
+		}
+		for {
+			result := &PermissionLevelsResponse{}
+			err = conn.DoRequest(queryPermissionLevelList, rankVariables, result)
+			if err != nil {
+				plugin.Logger(ctx).Error("guardrails_permission_level.listPermissionLevel", "query_error", err)
+				return nil, err
+			}
+			levels = append(levels, result.PermissionLevels.Items...)
+			if result.PermissionLevels.Paging.Next == "" {
+				break
+			}
+			rankVariables["next_token"] = result.PermissionLevels.Paging.Next
+		}
+		ranks = permissionLevelRanks(levels)
+	}
+
+	for {
+		result := &PermissionLevelsResponse{}
+		err = conn.DoRequest(queryPermissionLevelList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_permission_level.listPermissionLevel", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.PermissionLevels.Items {
+			if rank, ok := ranks[r.Turbot.ID]; ok {
+				r.Rank = &rank
+			}
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.PermissionLevels.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.PermissionLevels.Paging.Next
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,135 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsPermissionType(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_permission_type",
+		Description: "Permission types, like Turbot, AWS or Azure, that permissions can be granted for in Turbot Guardrails.",
+		List: &plugin.ListConfig{
+			Hydrate: listPermissionType,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "uri", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the permission type.", Hydrate: permissionHydrateId},
+			{Name: "uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the permission type.", Hydrate: permissionHydrateUri},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title of the permission type.", Hydrate: permissionHydrateTitle},
+			{Name: "trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title with full path of the permission type.", Hydrate: permissionHydrateTrunkTitle},
+			{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Description of the permission type.", Hydrate: permissionHydrateDescription},
+			// Other columns
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "AKA (also known as) identifiers for the permission type.", Hydrate: permissionHydrateAkas},
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the permission type was first discovered by Turbot. (It may have been created earlier.)", Hydrate: permissionHydrateCreateTimestamp},
+			{Name: "mod_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the mod that contains the permission type.", Hydrate: permissionHydrateModUri},
+			{Name: "parent_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID for the parent of this permission type.", Hydrate: permissionHydrateParentId},
+			{Name: "path", Type: proto.ColumnType_JSON, Transform: transform.FromValue().Transform(pathToArray), Description: "Hierarchy path with all identifiers of ancestors of the permission type.", Hydrate: permissionHydratePath},
+			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the permission type was last updated in Turbot.", Hydrate: permissionHydrateUpdateTimestamp},
+			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier for this version of the permission type.", Hydrate: permissionHydrateVersionId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	// Permission types and levels have the same shape
+	permissionFields = `
+      description
+      modUri
+      title
+      trunk {
+        title
+      }
+      turbot {
+        akas
+        createTimestamp
+        id
+        parentId
+        path
+        updateTimestamp
+        versionId
+      }
+      uri
+`
+
+	queryPermissionTypeList = `
+query permissionTypeList($filter: [String!], $next_token: String) {
+  permissionTypes(filter: $filter, paging: $next_token) {
+    items {` + permissionFields + `    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listPermissionType(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_permission_type.listPermissionType", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	// Additional filters
+	if quals["uri"] != nil {
+		filters = append(filters, fmt.Sprintf("permissionTypeId:%s permissionTypeLevel:self", getQualListValues(ctx, quals, "uri", "string")))
+	}
+
+	// Setting a high limit and page all results
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+
+	// Setting page limit
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_permission_type.listPermissionType", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_permission_type.listPermissionType", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &PermissionTypesResponse{}
+		err = conn.DoRequest(queryPermissionTypeList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_permission_type.listPermissionType", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.PermissionTypes.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.PermissionTypes.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.PermissionTypes.Paging.Next
+	}
+
+	return nil, nil
+}
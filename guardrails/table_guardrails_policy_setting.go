@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 
+	"github.com/turbot/steampipe-plugin-guardrails/errors"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -27,6 +28,10 @@ func tableGuardrailsPolicySetting(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: listPolicySetting,
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getPolicySetting,
+		},
 		Columns: []*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the policy setting.", Hydrate: policySettingHydrateId},
@@ -104,6 +109,46 @@ query policySettingList($filter: [String!], $next_token: String, $includePolicyS
     }
   }
 }
+`
+
+	queryPolicySettingGet = `
+query policySettingGet($id: ID!, $includePolicySettingDefault: Boolean!, $includePolicySettingException: Boolean!, $includePolicySettingInput: Boolean!, $includePolicySettingIsCalculated: Boolean!, $includePolicySettingNote: Boolean!, $includePolicySettingOrphan: Boolean!, $includePolicySettingPrecedence: Boolean!, $includePolicySettingResourceTrunkTitle: Boolean!, $includePolicySettingTemplate: Boolean!, $includePolicySettingTemplateInput: Boolean!, $includePolicySettingTypeUri: Boolean!, $includePolicySettingTypeTrunkTitle: Boolean!, $includePolicySettingTurbotId: Boolean!, $includePolicySettingTurbotTimestamp: Boolean!, $includePolicySettingTurbotCreateTimestamp: Boolean!, $includePolicySettingTurbotUpdateTimestamp: Boolean!, $includePolicySettingTurbotVersionId: Boolean!, $includePolicySettingTurbotPolicyTypeId: Boolean!, $includePolicySettingTurbotResourceId: Boolean!, $includePolicySettingValidFromTimestamp: Boolean!, $includePolicySettingValidToTimestamp: Boolean!, $includePolicySettingValue: Boolean!, $includePolicySettingValueSource: Boolean!) {
+  policySetting(id: $id) {
+    default @include(if: $includePolicySettingDefault)
+    exception @include(if: $includePolicySettingException)
+    input @include(if: $includePolicySettingInput)
+    isCalculated @include(if: $includePolicySettingIsCalculated)
+    note @include(if: $includePolicySettingNote)
+    orphan @include(if: $includePolicySettingOrphan)
+    precedence @include(if: $includePolicySettingPrecedence)
+    resource {
+      trunk {
+        title @include(if: $includePolicySettingResourceTrunkTitle)
+      }
+    }
+    template @include(if: $includePolicySettingTemplate)
+    templateInput @include(if: $includePolicySettingTemplateInput)
+    type {
+      uri @include(if: $includePolicySettingTypeUri)
+      trunk {
+        title @include(if: $includePolicySettingTypeTrunkTitle)
+      }
+    }
+    turbot {
+      id @include(if: $includePolicySettingTurbotId)
+      timestamp @include(if: $includePolicySettingTurbotTimestamp)
+      createTimestamp @include(if: $includePolicySettingTurbotCreateTimestamp)
+      updateTimestamp @include(if: $includePolicySettingTurbotUpdateTimestamp)
+      versionId @include(if: $includePolicySettingTurbotVersionId)
+      policyTypeId @include(if: $includePolicySettingTurbotPolicyTypeId)
+      resourceId @include(if: $includePolicySettingTurbotResourceId)
+    }
+    validFromTimestamp @include(if: $includePolicySettingValidFromTimestamp)
+    validToTimestamp @include(if: $includePolicySettingValidToTimestamp)
+    value @include(if: $includePolicySettingValue)
+    valueSource @include(if: $includePolicySettingValueSource)
+  }
+}
 `
 )
 
@@ -194,6 +239,13 @@ func listPolicySetting(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 			plugin.Logger(ctx).Error("guardrails_policy_setting.listPolicySetting", "query_error", err)
 			return nil, err
 		}
+		// Lets a join to guardrails_resource on resource_id batch its lookups
+		resourceIds := []string{}
+		for _, r := range result.PolicySettings.Items {
+			resourceIds = append(resourceIds, r.Turbot.ResourceID)
+		}
+		hintResourceIds(d, resourceIds)
+
 		for _, r := range result.PolicySettings.Items {
 			d.StreamListItem(ctx, r)
 
@@ -210,3 +262,28 @@ func listPolicySetting(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 
 	return nil, nil
 }
+
+func getPolicySetting(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_policy_setting.getPolicySetting", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"id": d.EqualsQuals["id"].GetInt64Value(),
+	}
+
+	appendPolicySettingColumnIncludes(&variables, d.QueryContext.Columns)
+
+	result := &PolicySettingResponse{}
+	err = conn.DoRequest(queryPolicySettingGet, variables, result)
+	if err != nil {
+		if errors.NotFoundError(err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("guardrails_policy_setting.getPolicySetting", "query_error", err)
+		return nil, err
+	}
+	return result.PolicySetting, nil
+}
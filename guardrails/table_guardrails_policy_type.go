@@ -133,12 +133,6 @@ query policyTypeGet($id: ID!, $includePolicyTypeCategoryTurbotId: Boolean!, $inc
 )
 
 func listPolicyType(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	conn, err := connect(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("guardrails_policy_type.listPolicyType", "connection_error", err)
-		return nil, err
-	}
-
 	filters := []string{}
 	quals := d.EqualsQuals
 
@@ -148,47 +142,31 @@ func listPolicyType(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 	}
 
 	// Setting a high limit and page all results
-	var pageLimit int64 = 5000
-
-	// Adjust page limit, if less than default value
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < pageLimit {
-			pageLimit = *limit
-		}
-	}
-
-	// Setting page limit
+	pageLimit := getCataloguePageLimit(d)
 	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
 
 	plugin.Logger(ctx).Debug("guardrails_policy_type.listPolicyType", "quals", quals)
 	plugin.Logger(ctx).Debug("guardrails_policy_type.listPolicyType", "filters", filters)
 
 	variables := map[string]interface{}{
-		"filter":     filters,
-		"next_token": "",
+		"filter": filters,
 	}
 	appendPolicyTypeColumnIncludes(&variables, d.QueryContext.Columns)
 
-	for {
-		result := &PolicyTypesResponse{}
-		err = conn.DoRequest(queryPolicyTypeList, variables, result)
-		if err != nil {
-			plugin.Logger(ctx).Error("guardrails_policy_type.listPolicyType", "query_error", err)
-			return nil, err
-		}
+	err := listCatalogue[PolicyTypesResponse](ctx, d, queryPolicyTypeList, variables, func(result *PolicyTypesResponse) bool {
 		for _, r := range result.PolicyTypes.Items {
 			d.StreamListItem(ctx, r)
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+				return false
 			}
 		}
-		if result.PolicyTypes.Paging.Next == "" {
-			break
-		}
-		variables["next_token"] = result.PolicyTypes.Paging.Next
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_policy_type.listPolicyType", "query_error", err)
+		return nil, err
 	}
 	return nil, nil
 }
@@ -20,6 +20,7 @@ func tableGuardrailsPolicyValue(ctx context.Context) *plugin.Table {
 				{Name: "state", Require: plugin.Optional},
 				{Name: "policy_type_id", Require: plugin.Optional},
 				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "aka", Require: plugin.Optional},
 				{Name: "resource_type_id", Require: plugin.Optional},
 				{Name: "filter", Require: plugin.Optional},
 			},
@@ -41,6 +42,7 @@ func tableGuardrailsPolicyValue(ctx context.Context) *plugin.Table {
 			{Name: "type_mod_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the mod that contains the policy value.", Hydrate: policyValueHydrateTypeModUri},
 
 			// Other columns
+			{Name: "aka", Type: proto.ColumnType_STRING, Transform: transform.FromValue().Transform(matchedAka), Description: "AKA (also known as) identifier of the resource, e.g. an AWS ARN, used to look up the policy values.", Hydrate: policyValueHydrateResourceAkas},
 			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used for this policy value list."},
 			{Name: "policy_type_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the policy type for this policy value.", Hydrate: policyValueHydratePolicyTypeId},
 			{Name: "policy_type_default_template", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Default template used to calculate template-based policy values. Should be a Jinja based YAML string.", Hydrate: policyValueHydratePolicyTypeDefaultTemplate},
@@ -58,7 +60,7 @@ func tableGuardrailsPolicyValue(ctx context.Context) *plugin.Table {
 
 const (
 	queryPolicyValueList = `
-query MyQuery($filter: [String!], $next_token: String, $includePolicyValueDefault: Boolean!, $includePolicyValue: Boolean!, $includePolicyValueState: Boolean!, $includePolicyValueSecretValue: Boolean!, $includePolicyValueIsCalculated: Boolean!, $includePolicyValuePrecedence: Boolean!, $includePolicyValueTypeModUri: Boolean!, $includePolicyValueTypeDefaultTemplate: Boolean!, $includePolicyValueTypeTitle: Boolean!, $includePolicyValueTypeTrunkTitle: Boolean!, $includePolicyValueResourceTrunkTitle: Boolean!, $includePolicyValueTurbotId: Boolean!, $includePolicyValueTurbotPolicyTypeId: Boolean!, $includePolicyValueTurbotResourceId: Boolean!, $includePolicyValueTurbotResourceTypeId: Boolean!, $includePolicyValueTurbotSettingId: Boolean!, $includePolicyValueTurbotCreateTimestamp: Boolean!, $includePolicyValueTurbotTimestamp: Boolean!, $includePolicyValueTurbotUpdateTimestamp: Boolean!, $includePolicyValueTurbotVersionId: Boolean!, $includePolicyValueDependentControls: Boolean!, $includePolicyValueDependentPolicyValues: Boolean!) {
+query MyQuery($filter: [String!], $next_token: String, $includePolicyValueDefault: Boolean!, $includePolicyValue: Boolean!, $includePolicyValueState: Boolean!, $includePolicyValueSecretValue: Boolean!, $includePolicyValueIsCalculated: Boolean!, $includePolicyValuePrecedence: Boolean!, $includePolicyValueTypeModUri: Boolean!, $includePolicyValueTypeDefaultTemplate: Boolean!, $includePolicyValueTypeTitle: Boolean!, $includePolicyValueTypeTrunkTitle: Boolean!, $includePolicyValueResourceTrunkTitle: Boolean!, $includePolicyValueTurbotId: Boolean!, $includePolicyValueTurbotPolicyTypeId: Boolean!, $includePolicyValueTurbotResourceId: Boolean!, $includePolicyValueTurbotResourceTypeId: Boolean!, $includePolicyValueTurbotSettingId: Boolean!, $includePolicyValueTurbotCreateTimestamp: Boolean!, $includePolicyValueTurbotTimestamp: Boolean!, $includePolicyValueTurbotUpdateTimestamp: Boolean!, $includePolicyValueTurbotVersionId: Boolean!, $includePolicyValueDependentControls: Boolean!, $includePolicyValueDependentPolicyValues: Boolean!, $includePolicyValueResourceAkas: Boolean!) {
   policyValues(filter: $filter, paging: $next_token) {
     items {
       default @include(if: $includePolicyValueDefault)
@@ -79,6 +81,9 @@ query MyQuery($filter: [String!], $next_token: String, $includePolicyValueDefaul
         trunk {
           title @include(if: $includePolicyValueResourceTrunkTitle)
         }
+        turbot {
+          akas @include(if: $includePolicyValueResourceAkas)
+        }
       }
       turbot {
         id @include(if: $includePolicyValueTurbotId)
@@ -146,11 +151,20 @@ func listPolicyValue(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	filters := []string{}
 	quals := d.EqualsQuals
 
+	// Both select the resource, and two resourceId terms can't be combined in
+	// one filter
+	if quals["aka"] != nil && quals["resource_id"] != nil {
+		return nil, fmt.Errorf("aka and resource_id cannot both be set, use one of them to select the resource")
+	}
+
 	filter := ""
 	if quals["filter"] != nil {
 		filter = quals["filter"].GetStringValue()
 		filters = append(filters, filter)
 	}
+	if quals["aka"] != nil && filterSelectsResource(filter) {
+		return nil, fmt.Errorf("aka and a resourceId filter cannot both be set, use one of them to select the resource")
+	}
 
 	// Additional filters
 	if quals["state"] != nil {
@@ -165,6 +179,10 @@ func listPolicyValue(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		filters = append(filters, fmt.Sprintf("resourceId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_id", "int64")))
 	}
 
+	if quals["aka"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "aka", "string")))
+	}
+
 	if quals["resource_type_id"] != nil {
 		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_id", "int64")))
 	}
@@ -195,6 +213,13 @@ func listPolicyValue(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 			plugin.Logger(ctx).Error("guardrails_policy_value.listPolicyValue", "query_error", err)
 			return nil, err
 		}
+		// Lets a join to guardrails_resource on resource_id batch its lookups
+		resourceIds := []string{}
+		for _, r := range result.PolicyValues.Items {
+			resourceIds = append(resourceIds, r.Turbot.ResourceId)
+		}
+		hintResourceIds(d, resourceIds)
+
 		for _, r := range result.PolicyValues.Items {
 			d.StreamListItem(ctx, r)
 
@@ -0,0 +1,247 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsPolicyValueExplain(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_policy_value_explain",
+		Description: "Candidate policy settings up the resource hierarchy that were considered when resolving a policy value.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_id", Require: plugin.Required},
+				{Name: "policy_type_uri", Require: plugin.Required},
+			},
+			Hydrate: listPolicyValueExplain,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("resource_id"), Description: "ID of the resource the policy value is resolved for."},
+			{Name: "policy_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromQual("policy_type_uri"), Description: "URI of the policy type being resolved."},
+			{Name: "depth", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Position of the setting resource in the hierarchy path, starting at 0 for the Turbot root. Settings on a smart folder take the position of the resource it is attached to.", Hydrate: policyValueExplainHydrateDepth},
+			{Name: "setting_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the candidate policy setting.", Hydrate: policyValueExplainHydrateSettingId},
+			{Name: "setting_resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource the candidate policy setting is defined on.", Hydrate: policyValueExplainHydrateSettingResourceId},
+			{Name: "setting_resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource the candidate policy setting is defined on.", Hydrate: policyValueExplainHydrateSettingResourceTrunkTitle},
+			{Name: "via_smart_folder", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if the candidate policy setting is defined on a smart folder attached to the resource or one of its ancestors.", Hydrate: policyValueExplainHydrateViaSmartFolder},
+			{Name: "precedence", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Precedence of the setting: REQUIRED or RECOMMENDED.", Hydrate: policyValueExplainHydratePrecedence},
+			{Name: "is_effective", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if this setting is the one that took effect for the resolved policy value.", Hydrate: policyValueExplainHydrateIsEffective},
+			{Name: "exception", Type: proto.ColumnType_BOOL, Transform: transform.FromValue().Transform(intToBool), Description: "True if this setting is an exception to a higher level setting.", Hydrate: policyValueExplainHydrateException},
+			{Name: "orphan", Type: proto.ColumnType_BOOL, Transform: transform.FromValue().Transform(intToBool), Description: "True if this setting is orphaned by a higher level setting.", Hydrate: policyValueExplainHydrateOrphan},
+			{Name: "value", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Value of the candidate policy setting (for non-calculated policy settings).", Hydrate: policyValueExplainHydrateValue},
+			// Other columns
+			{Name: "is_calculated", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if this is a policy setting will be calculated for each value.", Hydrate: policyValueExplainHydrateIsCalculated},
+			{Name: "policy_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the policy type.", Hydrate: policyValueExplainHydratePolicyTypeTrunkTitle},
+			{Name: "resolved_precedence", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Precedence of the resolved policy value for the resource.", Hydrate: policyValueExplainHydrateResolvedPrecedence},
+			{Name: "resolved_value", Type: proto.ColumnType_STRING, Transform: transform.FromValue().Transform(convToString), Description: "Resolved policy value for the resource.", Hydrate: policyValueExplainHydrateResolvedValue},
+			{Name: "valid_from_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the policy setting becomes valid.", Hydrate: policyValueExplainHydrateValidFromTimestamp},
+			{Name: "valid_to_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the policy setting expires.", Hydrate: policyValueExplainHydrateValidToTimestamp},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+type PolicyValueExplain struct {
+	Depth          int
+	IsEffective    bool
+	ViaSmartFolder bool
+	Resolved       PolicyValue
+	Setting        PolicySetting
+}
+
+const (
+	queryPolicyValueExplainResolved = `
+query policyValueExplainResolved($id: ID!, $filter: [String!]) {
+  resource(id: $id) {
+    turbot {
+      path
+    }
+  }
+  policyValues(filter: $filter) {
+    items {
+      precedence
+      value
+      turbot {
+        settingId
+      }
+    }
+  }
+}
+`
+
+	queryPolicyValueExplainSettings = `
+query policyValueExplainSettings($filter: [String!], $next_token: String) {
+  policySettings(filter: $filter, paging: $next_token) {
+    items {
+      exception
+      isCalculated
+      orphan
+      precedence
+      resource {
+        trunk {
+          title
+        }
+      }
+      type {
+        uri
+        trunk {
+          title
+        }
+      }
+      turbot {
+        id
+        resourceId
+      }
+      validFromTimestamp
+      validToTimestamp
+      value
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listPolicyValueExplain(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_policy_value_explain.listPolicyValueExplain", "connection_error", err)
+		return nil, err
+	}
+
+	quals := d.EqualsQuals
+	resourceId := quals["resource_id"].GetInt64Value()
+	policyTypeUri := escapeQualString(ctx, quals, "policy_type_uri")
+
+	// Resolve the policy value and the hierarchy path of the resource in a single request
+	resolvedVariables := map[string]interface{}{
+		"id":     resourceId,
+		"filter": []string{fmt.Sprintf("resourceId:%d resourceTypeLevel:self policyTypeId:'%s' policyTypeLevel:self limit:1", resourceId, policyTypeUri)},
+	}
+	resolved := &PolicyValueExplainResponse{}
+	err = conn.DoRequest(queryPolicyValueExplainResolved, resolvedVariables, resolved)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_policy_value_explain.listPolicyValueExplain", "query_error", err)
+		return nil, err
+	}
+
+	// A resource that doesn't exist has no path, so there is nothing to explain
+	path := resolved.Resource.Turbot.Path
+	if path == "" {
+		return nil, nil
+	}
+
+	// Position of each resource in the path, root first
+	pathIds := strings.Split(path, ".")
+	depths := map[string]int{}
+	for i, id := range pathIds {
+		depths[id] = i
+	}
+
+	// Settings on smart folders attached to the resource or its ancestors are
+	// candidates too. They take the position of the resource they are attached
+	// to, the lowest one if the smart folder is attached at several levels.
+	attached, err := listAttachedSmartFolders(conn, strings.Join(pathIds, ","))
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_policy_value_explain.listPolicyValueExplain", "query_error", err)
+		return nil, err
+	}
+	smartFolderDepths := map[string]int{}
+	for resourceId, smartFolderIds := range attached {
+		for _, id := range smartFolderIds {
+			if depth, ok := smartFolderDepths[id]; !ok || depths[resourceId] > depth {
+				smartFolderDepths[id] = depths[resourceId]
+			}
+		}
+	}
+	settingResourceIds := slices.Clone(pathIds)
+	for id := range smartFolderDepths {
+		settingResourceIds = append(settingResourceIds, id)
+	}
+
+	var resolvedValue PolicyValue
+	if len(resolved.PolicyValues.Items) > 0 {
+		resolvedValue = resolved.PolicyValues.Items[0]
+	}
+
+	// Setting a high limit and page all results, since the candidates are
+	// sorted before they are streamed
+	var pageLimit int64 = 5000
+
+	filters := []string{
+		fmt.Sprintf("resourceId:%s resourceTypeLevel:self", strings.Join(settingResourceIds, ",")),
+		fmt.Sprintf("policyTypeId:'%s' policyTypeLevel:self", policyTypeUri),
+		fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))),
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_policy_value_explain.listPolicyValueExplain", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	candidates := []PolicyValueExplain{}
+	for {
+		result := &PolicySettingsResponse{}
+		err = conn.DoRequest(queryPolicyValueExplainSettings, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_policy_value_explain.listPolicyValueExplain", "query_error", err)
+			return nil, err
+		}
+		for _, s := range result.PolicySettings.Items {
+			candidate := PolicyValueExplain{
+				IsEffective: resolvedValue.Turbot.SettingId != "" && resolvedValue.Turbot.SettingId == s.Turbot.ID,
+				Resolved:    resolvedValue,
+				Setting:     s,
+			}
+			if depth, ok := depths[s.Turbot.ResourceID]; ok {
+				candidate.Depth = depth
+			} else if depth, ok := smartFolderDepths[s.Turbot.ResourceID]; ok {
+				candidate.Depth = depth
+				candidate.ViaSmartFolder = true
+			} else {
+				// Only settings on the path and its smart folders are requested
+				plugin.Logger(ctx).Warn("guardrails_policy_value_explain.listPolicyValueExplain", "unexpected_setting_resource", s.Turbot.ResourceID)
+				continue
+			}
+			candidates = append(candidates, candidate)
+		}
+		if result.PolicySettings.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.PolicySettings.Paging.Next
+	}
+
+	// Stream candidates in hierarchy order, from the Turbot root down to the
+	// resource. Settings directly on a resource override those on its attached
+	// smart folders, so smart folder settings come first at each level.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Depth != candidates[j].Depth {
+			return candidates[i].Depth < candidates[j].Depth
+		}
+		return candidates[i].ViaSmartFolder && !candidates[j].ViaSmartFolder
+	})
+
+	for _, c := range candidates {
+		d.StreamListItem(ctx, c)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -19,12 +19,17 @@ func tableGuardrailsResource(ctx context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "id", Require: plugin.Optional},
+				{Name: "aka", Require: plugin.Optional},
 				{Name: "resource_type_id", Require: plugin.Optional},
 				{Name: "resource_type_uri", Require: plugin.Optional},
 				{Name: "filter", Require: plugin.Optional},
 			},
 			Hydrate: listResource,
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getResource,
+		},
 		Columns: []*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the resource.", Hydrate: resourceHydrateId},
@@ -33,6 +38,7 @@ func tableGuardrailsResource(ctx context.Context) *plugin.Table {
 			{Name: "tags", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "Tags for the resource.", Hydrate: resourceHydrateTags},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "AKA (also known as) identifiers for the resource.", Hydrate: resourceHydrateAkas},
 			// Other columns
+			{Name: "aka", Type: proto.ColumnType_STRING, Transform: transform.FromValue().Transform(matchedAka), Description: "AKA (also known as) identifier used to look up the resource, e.g. an AWS ARN.", Hydrate: resourceHydrateAkas},
 			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the resource was first discovered by Turbot. (It may have been created earlier.)", Hydrate: resourceHydrateCreateTimestamp},
 			{Name: "data", Type: proto.ColumnType_JSON, Description: "Resource data.", Transform: transform.FromValue(), Hydrate: resourceHydrateData},
 			{Name: "object", Type: proto.ColumnType_JSON, Description: "Extended Resource data.", Transform: transform.FromValue(), Hydrate: resourceHydrateObject},
@@ -83,6 +89,35 @@ query resourceList($filter: [String!], $next_token: String, $includeResourceObje
     }
   }
 }
+`
+
+	queryResourceGet = `
+query resourceGet($id: ID!, $includeResourceObject: Boolean!, $includeResourceData: Boolean!, $includeResourceMetadata: Boolean!, $includeResourceTrunkTitle: Boolean!, $includeResourceId: Boolean!, $includeResourceTitle: Boolean!, $includeResourceTags: Boolean!, $includeResourceAkas: Boolean!, $includeResourceTimestamp: Boolean!, $includeResourceCreateTimestamp: Boolean!, $includeResourceUpdateTimestamp: Boolean!, $includeResourceVersionId: Boolean!, $includeResourceParentId: Boolean!, $includeResourcePath: Boolean!, $includeResourceTypeId: Boolean!, $includeResourceTypeUri: Boolean!) {
+  resource(id: $id) {
+    data @include(if: $includeResourceData)
+    object @include(if: $includeResourceObject)
+    metadata @include(if: $includeResourceMetadata)
+    trunk {
+      title @include(if: $includeResourceTrunkTitle)
+    }
+    turbot {
+      id @include(if: $includeResourceId)
+      title @include(if: $includeResourceTitle)
+      tags @include(if: $includeResourceTags)
+      akas @include(if: $includeResourceAkas)
+      timestamp @include(if: $includeResourceTimestamp)
+      createTimestamp @include(if: $includeResourceCreateTimestamp)
+      updateTimestamp @include(if: $includeResourceUpdateTimestamp)
+      versionId @include(if: $includeResourceVersionId)
+      parentId @include(if: $includeResourceParentId)
+      path @include(if: $includeResourcePath)
+      resourceTypeId @include(if: $includeResourceTypeId)
+    }
+    type {
+      uri @include(if: $includeResourceTypeUri)
+    }
+  }
+}
 `
 )
 
@@ -102,10 +137,19 @@ func listResource(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		filters = append(filters, filter)
 	}
 
+	// Both select the resource, and two resourceId terms can't be combined in
+	// one filter
+	if quals["aka"] != nil && filterSelectsResource(filter) {
+		return nil, fmt.Errorf("aka and a resourceId filter cannot both be set, use one of them to select the resource")
+	}
+
 	// Additional filters
 	if quals["id"] != nil {
 		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "id", "int64")))
 	}
+	if quals["aka"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "aka", "string")))
+	}
 	if quals["resource_type_id"] != nil {
 		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_id", "int64")))
 	}
@@ -173,3 +217,22 @@ func listResource(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 
 	return nil, nil
 }
+
+func getResource(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	quals := d.EqualsQuals
+	id := strconv.FormatInt(quals["id"].GetInt64Value(), 10)
+
+	variables := map[string]interface{}{}
+	appendResourceColumnIncludes(&variables, d.QueryContext.Columns)
+
+	// Concurrent lookups and join rescans are merged into batched requests
+	resource, err := getResourceBatched(ctx, d, id, variables)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_resource.getResource", "query_error", err)
+		return nil, err
+	}
+	if resource == nil {
+		return nil, nil
+	}
+	return *resource, nil
+}
@@ -0,0 +1,133 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsResourceAncestor(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_resource_ancestor",
+		Description: "Ancestors of a resource in the Turbot Guardrails resource hierarchy.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_id", Require: plugin.Required},
+			},
+			Hydrate: listResourceAncestor,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("resource_id"), Description: "ID of the resource to list the ancestors of."},
+			{Name: "depth", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of levels the ancestor is above the resource. The parent has a depth of 1.", Hydrate: resourceHierarchyHydrateDepth},
+			{Name: "ancestor_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the ancestor resource.", Hydrate: resourceHierarchyHydrateId},
+			{Name: "ancestor_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title of the ancestor resource.", Hydrate: resourceHierarchyHydrateTitle},
+			{Name: "ancestor_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the ancestor resource.", Hydrate: resourceHierarchyHydrateTrunkTitle},
+			{Name: "ancestor_resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the ancestor resource.", Hydrate: resourceHierarchyHydrateResourceTypeUri},
+			// Other columns
+			{Name: "ancestor_parent_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID for the parent of the ancestor resource. For the Turbot root resource this is null.", Hydrate: resourceHierarchyHydrateParentId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryResourceHierarchy = `
+query resourceHierarchy($filter: [String!], $next_token: String) {
+  resources(filter: $filter, paging: $next_token) {
+    items {
+      trunk {
+        title
+      }
+      turbot {
+        id
+        title
+        parentId
+        path
+      }
+      type {
+        uri
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listResourceAncestor(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_resource_ancestor.listResourceAncestor", "connection_error", err)
+		return nil, err
+	}
+
+	resourceId := d.EqualsQuals["resource_id"].GetInt64Value()
+
+	// The resource itself is included so its path can be used to work out the
+	// depth of each ancestor
+	filters := []string{
+		fmt.Sprintf("resourceId:%d level:self,ancestor", resourceId),
+		"limit:5000",
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_resource_ancestor.listResourceAncestor", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	// A resource only has a handful of ancestors, so collect them all before
+	// streaming
+	var self *Resource
+	ancestors := []Resource{}
+	for {
+		result := &ResourcesResponse{}
+		err = conn.DoRequest(queryResourceHierarchy, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_resource_ancestor.listResourceAncestor", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.Resources.Items {
+			if r.Turbot.ID == fmt.Sprint(resourceId) {
+				resource := r
+				self = &resource
+			} else {
+				ancestors = append(ancestors, r)
+			}
+		}
+		if result.Resources.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Resources.Paging.Next
+	}
+
+	// A resource that doesn't exist has no ancestors
+	if self == nil {
+		return nil, nil
+	}
+
+	for _, r := range ancestors {
+		d.StreamListItem(ctx, ResourceHierarchyItem{Depth: pathDepth(self.Turbot.Path) - pathDepth(r.Turbot.Path), Resource: r})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// pathDepth returns the number of resources in a resource path, e.g.
+// 191382256916538.191382256916539 has a depth of 2
+func pathDepth(path string) int {
+	return len(strings.Split(path, "."))
+}
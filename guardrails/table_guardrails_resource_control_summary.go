@@ -0,0 +1,174 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsResourceControlSummary(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_resource_control_summary",
+		Description: "Number of controls in each state for each resource, counted by Turbot Guardrails.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_type_id", Require: plugin.Optional},
+				{Name: "resource_type_uri", Require: plugin.Optional},
+				{Name: "ancestor_id", Require: plugin.Optional},
+				{Name: "filter", Require: plugin.Optional},
+			},
+			Hydrate: listResourceControlSummary,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource.", Hydrate: resourceControlSummaryHydrateResourceId},
+			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource.", Hydrate: resourceControlSummaryHydrateResourceTrunkTitle},
+			{Name: "alarm", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of controls for the resource in the alarm state.", Hydrate: resourceControlSummaryHydrateAlarm},
+			{Name: "error", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of controls for the resource in the error state.", Hydrate: resourceControlSummaryHydrateError},
+			{Name: "invalid", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of controls for the resource in the invalid state.", Hydrate: resourceControlSummaryHydrateInvalid},
+			{Name: "ok", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of controls for the resource in the ok state.", Hydrate: resourceControlSummaryHydrateOk},
+			{Name: "skipped", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of controls for the resource in the skipped state.", Hydrate: resourceControlSummaryHydrateSkipped},
+			{Name: "tbd", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of controls for the resource in the tbd state.", Hydrate: resourceControlSummaryHydrateTbd},
+			// Other columns
+			{Name: "ancestor_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("ancestor_id"), Description: "ID of a resource to limit the results to resources at or below it in the hierarchy."},
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used to select the controls to count."},
+			{Name: "resource_type_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource type of the resource.", Hydrate: resourceControlSummaryHydrateResourceTypeId},
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the resource.", Hydrate: resourceControlSummaryHydrateResourceTypeUri},
+			{Name: "total", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Total number of controls for the resource.", Hydrate: resourceControlSummaryHydrateTotal},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryResourceControlSummaryList = `
+query resourceControlSummaryList($filter: [String!], $next_token: String) {
+  controlSummariesByResource(filter: $filter, paging: $next_token) {
+    items {
+      resource {
+        trunk {
+          title
+        }
+        turbot {
+          id
+        }
+        type {
+          uri
+          turbot {
+            id
+          }
+        }
+      }
+      summary {
+        control {
+          alarm
+          error
+          invalid
+          ok
+          skipped
+          tbd
+          total
+        }
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listResourceControlSummary(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_resource_control_summary.listResourceControlSummary", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	// Both select resources by id, and two resourceId terms can't be combined
+	// in one filter
+	if quals["resource_id"] != nil && quals["ancestor_id"] != nil {
+		return nil, fmt.Errorf("resource_id and ancestor_id cannot both be set, use one of them to select the resources")
+	}
+
+	filter := ""
+	if quals["filter"] != nil {
+		filter = quals["filter"].GetStringValue()
+		filters = append(filters, filter)
+	}
+
+	// Additional filters
+	if quals["resource_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "resource_id", "int64")))
+	}
+	if quals["resource_type_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_id", "int64")))
+	}
+	if quals["resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+	}
+	if quals["ancestor_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self,descendant", getQualListValues(ctx, quals, "ancestor_id", "int64")))
+	}
+
+	// Default to a very large page size. Page sizes earlier in the filter string
+	// win, so this is only used as a fallback.
+	pageResults := false
+	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
+	if !re.MatchString(filter) {
+		// The caller did not specify a limit, so set a high limit and page all
+		// results.
+		pageResults = true
+		var pageLimit int64 = 5000
+
+		// Adjust page limit, if less than default value
+		limit := d.QueryContext.Limit
+		if d.QueryContext.Limit != nil {
+			if *limit < pageLimit {
+				pageLimit = *limit
+			}
+		}
+		filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_resource_control_summary.listResourceControlSummary", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_resource_control_summary.listResourceControlSummary", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &ResourceControlSummariesResponse{}
+		err = conn.DoRequest(queryResourceControlSummaryList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_resource_control_summary.listResourceControlSummary", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.ControlSummariesByResource.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if !pageResults || result.ControlSummariesByResource.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ControlSummariesByResource.Paging.Next
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsResourceDescendant(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_resource_descendant",
+		Description: "Descendants of a resource in the Turbot Guardrails resource hierarchy.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_id", Require: plugin.Required},
+				{Name: "descendant_resource_type_uri", Require: plugin.Optional},
+			},
+			Hydrate: listResourceDescendant,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("resource_id"), Description: "ID of the resource to list the descendants of."},
+			{Name: "depth", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of levels the descendant is below the resource. Children have a depth of 1.", Hydrate: resourceHierarchyHydrateDepth},
+			{Name: "descendant_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the descendant resource.", Hydrate: resourceHierarchyHydrateId},
+			{Name: "descendant_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title of the descendant resource.", Hydrate: resourceHierarchyHydrateTitle},
+			{Name: "descendant_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the descendant resource.", Hydrate: resourceHierarchyHydrateTrunkTitle},
+			{Name: "descendant_resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the descendant resource.", Hydrate: resourceHierarchyHydrateResourceTypeUri},
+			// Other columns
+			{Name: "descendant_parent_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID for the parent of the descendant resource.", Hydrate: resourceHierarchyHydrateParentId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+func listResourceDescendant(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_resource_descendant.listResourceDescendant", "connection_error", err)
+		return nil, err
+	}
+
+	resourceId := d.EqualsQuals["resource_id"].GetInt64Value()
+
+	// The path of the resource is needed to work out the depth of each
+	// descendant
+	selfResult := &ResourcesResponse{}
+	err = conn.DoRequest(queryResourceHierarchy, map[string]interface{}{"filter": []string{fmt.Sprintf("resourceId:%d level:self", resourceId)}, "next_token": ""}, selfResult)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_resource_descendant.listResourceDescendant", "query_error", err)
+		return nil, err
+	}
+
+	// A resource that doesn't exist has no descendants
+	if len(selfResult.Resources.Items) == 0 {
+		return nil, nil
+	}
+	selfDepth := pathDepth(selfResult.Resources.Items[0].Turbot.Path)
+
+	filters := []string{fmt.Sprintf("resourceId:%d level:descendant", resourceId)}
+	quals := d.EqualsQuals
+
+	if quals["descendant_resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "descendant_resource_type_uri", "string")))
+	}
+
+	// Setting a high limit and page all results
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+
+	// Setting page limit
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_resource_descendant.listResourceDescendant", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &ResourcesResponse{}
+		err = conn.DoRequest(queryResourceHierarchy, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_resource_descendant.listResourceDescendant", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.Resources.Items {
+			// Skip the resource itself
+			if r.Turbot.ID == fmt.Sprint(resourceId) {
+				continue
+			}
+
+			d.StreamListItem(ctx, ResourceHierarchyItem{Depth: pathDepth(r.Turbot.Path) - selfDepth, Resource: r})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.Resources.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Resources.Paging.Next
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,127 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsResourceSummary(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_resource_summary",
+		Description: "Number of resources of each resource type, counted by Turbot Guardrails.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_type_id", Require: plugin.Optional},
+				{Name: "resource_type_uri", Require: plugin.Optional},
+				{Name: "filter", Require: plugin.Optional},
+			},
+			Hydrate: listResourceSummary,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type.", Hydrate: resourceSummaryHydrateResourceTypeUri},
+			{Name: "resource_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource type.", Hydrate: resourceSummaryHydrateResourceTypeTrunkTitle},
+			{Name: "count", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of resources of the resource type matching the filter.", Hydrate: resourceSummaryHydrateCount},
+			// Other columns
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used to select the resources to count."},
+			{Name: "resource_type_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource type.", Hydrate: resourceSummaryHydrateResourceTypeId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryResourceSummaryList = `
+query resourceSummaryList($filter: [String!], $next_token: String) {
+  resourceSummariesByResourceType(filter: $filter, paging: $next_token) {
+    items {
+      type {
+        uri
+        trunk {
+          title
+        }
+        turbot {
+          id
+        }
+      }
+      summary {
+        resource {
+          total
+        }
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listResourceSummary(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_resource_summary.listResourceSummary", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	filter := ""
+	if quals["filter"] != nil {
+		filter = quals["filter"].GetStringValue()
+		filters = append(filters, filter)
+	}
+
+	// Additional filters
+	if quals["resource_type_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_id", "int64")))
+	}
+	if quals["resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+	}
+
+	// There is one row per resource type, so page through all of them unless
+	// the caller gave a limit in the filter field
+	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
+	if !re.MatchString(filter) {
+		filters = append(filters, "limit:5000")
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_resource_summary.listResourceSummary", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_resource_summary.listResourceSummary", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &ResourceSummariesResponse{}
+		err = conn.DoRequest(queryResourceSummaryList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_resource_summary.listResourceSummary", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.ResourceSummariesByResourceType.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.ResourceSummariesByResourceType.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.ResourceSummariesByResourceType.Paging.Next
+	}
+
+	return nil, nil
+}
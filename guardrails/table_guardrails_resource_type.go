@@ -116,12 +116,6 @@ query resourceGet($id: ID!, $includeResourceTypeCategoryId: Boolean!, $includeRe
 )
 
 func listResourceType(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	conn, err := connect(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("guardrails_resource_type.listResourceType", "connection_error", err)
-		return nil, err
-	}
-
 	filters := []string{}
 	quals := d.EqualsQuals
 
@@ -135,48 +129,32 @@ func listResourceType(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 	}
 
 	// Setting a high limit and page all results
-	var pageLimit int64 = 5000
-
-	// Adjust page limit, if less than default value
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < pageLimit {
-			pageLimit = *limit
-		}
-	}
-
-	// Setting page limit
+	pageLimit := getCataloguePageLimit(d)
 	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
 
 	plugin.Logger(ctx).Debug("guardrails_resource_type.listResourceType", "quals", quals)
 	plugin.Logger(ctx).Debug("guardrails_resource_type.listResourceType", "filters", filters)
 
 	variables := map[string]interface{}{
-		"filter":     filters,
-		"next_token": "",
+		"filter": filters,
 	}
 
 	appendResourceTypeColumnIncludes(&variables, d.QueryContext.Columns)
 
-	for {
-		result := &ResourceTypesResponse{}
-		err = conn.DoRequest(queryResourceTypeList, variables, result)
-		if err != nil {
-			plugin.Logger(ctx).Error("guardrails_resource_type.listResourceType", "query_error", err)
-			return nil, err
-		}
+	err := listCatalogue[ResourceTypesResponse](ctx, d, queryResourceTypeList, variables, func(result *ResourceTypesResponse) bool {
 		for _, r := range result.ResourceTypes.Items {
 			d.StreamListItem(ctx, r)
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+				return false
 			}
 		}
-		if result.ResourceTypes.Paging.Next == "" {
-			break
-		}
-		variables["next_token"] = result.ResourceTypes.Paging.Next
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_resource_type.listResourceType", "query_error", err)
+		return nil, err
 	}
 
 	return nil, nil
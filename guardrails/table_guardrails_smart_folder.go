@@ -3,8 +3,11 @@ package turbot
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/turbot/steampipe-plugin-guardrails/apiClient"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -16,6 +19,13 @@ func tableGuardrailsSmartFolder(ctx context.Context) *plugin.Table {
 		Description: "Smart folders allow policy settings to be attached as groups to resources.",
 		List: &plugin.ListConfig{
 			Hydrate: listSmartFolder,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "title", Require: plugin.Optional},
+				{Name: "parent_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "tag_key", Require: plugin.Optional},
+				{Name: "tag_value", Require: plugin.Optional},
+			},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("id"),
@@ -37,8 +47,11 @@ func tableGuardrailsSmartFolder(ctx context.Context) *plugin.Table {
 			{Name: "metadata", Type: proto.ColumnType_JSON, Description: "Resource custom metadata.", Hydrate: smartFolderHydrateMetadata, Transform: transform.FromValue()},
 			{Name: "parent_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID for the parent of this smart folder.", Hydrate: smartFolderHydrateParentId},
 			{Name: "path", Type: proto.ColumnType_JSON, Transform: transform.FromValue().Transform(pathToArray), Description: "Hierarchy path with all identifiers of ancestors of the smart folder.", Hydrate: smartFolderHydratePath},
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("resource_id"), Description: "ID of a resource the smart folder is attached to. Use this to find the smart folders attached to a resource."},
 			{Name: "resource_type_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource type for this smart folder.", Hydrate: smartFolderHydrateResourceTypeId},
 			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type for this smart folder.", Hydrate: smartFolderHydrateTypeUri},
+			{Name: "tag_key", Type: proto.ColumnType_STRING, Transform: transform.FromValue().TransformP(smartFolderMatchedTag, "key"), Hydrate: smartFolderHydrateTags, Description: "Key of a tag on the smart folder. Use this to find smart folders with a given tag."},
+			{Name: "tag_value", Type: proto.ColumnType_STRING, Transform: transform.FromValue().TransformP(smartFolderMatchedTag, "value"), Hydrate: smartFolderHydrateTags, Description: "Value of a tag on the smart folder. Use this to find smart folders with a given tag value."},
 			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the smart folder was last modified (created, updated or deleted).", Hydrate: smartFolderHydrateTimestamp},
 			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the smart folder was last updated in Turbot.", Hydrate: smartFolderHydrateUpdateTimestamp},
 			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier for this version of the smart folder.", Hydrate: smartFolderHydrateVersionId},
@@ -140,14 +153,61 @@ func listSmartFolder(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 			pageLimit = *limit
 		}
 	}
-	filter := fmt.Sprintf("resourceTypeId:'tmod:@turbot/turbot#/resource/types/smartFolder' resourceTypeLevel:self limit:%s", strconv.Itoa(int(pageLimit)))
+	filters := []string{"resourceTypeId:'tmod:@turbot/turbot#/resource/types/smartFolder' resourceTypeLevel:self"}
+	quals := d.EqualsQuals
+
+	// Additional filters
+	if quals["resource_id"] != nil {
+		// Resolve the smart folders attached to the resource(s) first, then
+		// restrict the smart folder list to those ids.
+		smartFolderIds, err := listAttachedSmartFolderIds(conn, getQualListValues(ctx, quals, "resource_id", "int64"))
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_smart_folder.listSmartFolder", "query_error", err)
+			return nil, err
+		}
+		if len(smartFolderIds) == 0 {
+			return nil, nil
+		}
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", strings.Join(smartFolderIds, ",")))
+	}
+
+	if quals["title"] != nil {
+		filters = append(filters, fmt.Sprintf("title:%s", getQualListValues(ctx, quals, "title", "string")))
+	}
+
+	if quals["parent_id"] != nil {
+		// Smart folders below the parent, the parent_id qual is rechecked on
+		// the returned rows so nested smart folders are excluded.
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:descendant", getQualListValues(ctx, quals, "parent_id", "int64")))
+	}
+
+	// A single tag key, and optionally value, is passed to Guardrails. Lists of
+	// keys or values are checked on the returned smart folders.
+	tagKeys := qualStringValues(quals["tag_key"])
+	tagValues := qualStringValues(quals["tag_value"])
+	if len(tagKeys) == 1 {
+		if len(tagValues) == 1 {
+			filters = append(filters, fmt.Sprintf("tags:%s=%s", getQualListValues(ctx, quals, "tag_key", "string"), getQualListValues(ctx, quals, "tag_value", "string")))
+		} else {
+			filters = append(filters, fmt.Sprintf("tags:%s", getQualListValues(ctx, quals, "tag_key", "string")))
+		}
+	}
+
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_smart_folder.listSmartFolder", "filters", filters)
 
 	variables := map[string]interface{}{
-		"filter":     filter,
+		"filter":     filters,
 		"next_token": "",
 	}
 
-	appendSmartFolderColumnIncludes(&variables, d.QueryContext.Columns)
+	cols := d.QueryContext.Columns
+	if len(tagKeys) > 0 || len(tagValues) > 0 {
+		// Tags are needed to check the tag quals on each smart folder
+		cols = append(slices.Clone(cols), "tags")
+	}
+	appendSmartFolderColumnIncludes(&variables, cols)
 
 	for {
 		result := &ResourcesResponse{}
@@ -157,6 +217,9 @@ func listSmartFolder(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 			return nil, err
 		}
 		for _, r := range result.Resources.Items {
+			if !smartFolderMatchesTag(r, tagKeys, tagValues) {
+				continue
+			}
 			d.StreamListItem(ctx, r)
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -196,3 +259,66 @@ func getSmartFolder(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 	}
 	return result.Resource, nil
 }
+
+// listAttachedSmartFolderIds returns the ids of the smart folders attached to the given resources
+func listAttachedSmartFolderIds(conn *apiClient.Client, resourceIds string) ([]string, error) {
+	attached, err := listAttachedSmartFolders(conn, resourceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, smartFolderIds := range attached {
+		ids = append(ids, smartFolderIds...)
+	}
+	return ids, nil
+}
+
+// listAttachedSmartFolders returns the ids of the smart folders attached to
+// each of the given resources, keyed by resource id
+func listAttachedSmartFolders(conn *apiClient.Client, resourceIds string) (map[string][]string, error) {
+	attachmentFilter := []string{"limit:5000"}
+	variables := map[string]interface{}{
+		"filter":            []string{fmt.Sprintf("resourceId:%s level:self", resourceIds), "limit:5000"},
+		"next_token":        "",
+		"attachment_filter": attachmentFilter,
+	}
+
+	attached := map[string][]string{}
+	for {
+		result := &SmartFolderAttachmentsResponse{}
+		err := conn.DoRequest(queryResourceSmartFolderAttachmentList, variables, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range result.Resources.Items {
+			smartFolders := r.AttachedSmartFolders
+			for {
+				for _, sf := range smartFolders.Items {
+					attached[r.Turbot.ID] = append(attached[r.Turbot.ID], sf.Turbot.ID)
+				}
+				if smartFolders.Paging.Next == "" {
+					break
+				}
+
+				pageResult := &SmartFolderAttachmentResourceResponse{}
+				pageVariables := map[string]interface{}{
+					"id":                    r.Turbot.ID,
+					"attachment_filter":     attachmentFilter,
+					"attachment_next_token": smartFolders.Paging.Next,
+				}
+				err = conn.DoRequest(queryResourceAttachedSmartFolders, pageVariables, pageResult)
+				if err != nil {
+					return nil, err
+				}
+				smartFolders = pageResult.Resource.AttachedSmartFolders
+			}
+		}
+		if result.Resources.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Resources.Paging.Next
+	}
+
+	return attached, nil
+}
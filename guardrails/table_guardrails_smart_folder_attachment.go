@@ -0,0 +1,336 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-guardrails/apiClient"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsSmartFolderAttachment(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_smart_folder_attachment",
+		Description: "Attachments of smart folders to resources, optionally expanded to every resource the smart folder governs.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "smart_folder_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "effective", Require: plugin.Optional},
+			},
+			Hydrate: listSmartFolderAttachment,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "smart_folder_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the smart folder.", Hydrate: smartFolderAttachmentHydrateSmartFolderId},
+			{Name: "smart_folder_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title of the smart folder.", Hydrate: smartFolderAttachmentHydrateSmartFolderTitle},
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource governed by the smart folder.", Hydrate: smartFolderAttachmentHydrateResourceId},
+			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource governed by the smart folder.", Hydrate: smartFolderAttachmentHydrateResourceTrunkTitle},
+			{Name: "attached_resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the resource the smart folder is attached to. This is the resource itself or one of its ancestors.", Hydrate: smartFolderAttachmentHydrateAttachedResourceId},
+			{Name: "effective", Type: proto.ColumnType_BOOL, Transform: transform.FromQual("effective"), Description: "If true, rows include every resource governed by the smart folder, i.e. the attached resources and their descendants. Otherwise only direct attachments are returned."},
+			// Other columns
+			{Name: "attached_resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the resource the smart folder is attached to.", Hydrate: smartFolderAttachmentHydrateAttachedResourceTrunkTitle},
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the resource governed by the smart folder.", Hydrate: smartFolderAttachmentHydrateResourceTypeUri},
+			{Name: "smart_folder_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Title with full path of the smart folder.", Hydrate: smartFolderAttachmentHydrateSmartFolderTrunkTitle},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+type SmartFolderAttachment struct {
+	SmartFolder      SmartFolderAttachmentItem
+	Resource         SmartFolderAttachmentItem
+	AttachedResource SmartFolderAttachmentItem
+}
+
+const (
+	smartFolderAttachmentItemFields = `
+        items {
+          trunk {
+            title
+          }
+          turbot {
+            id
+            title
+          }
+          type {
+            uri
+          }
+        }
+        paging {
+          next
+        }
+`
+
+	smartFolderAttachmentSelfFields = `
+      trunk {
+        title
+      }
+      turbot {
+        id
+        title
+      }
+      type {
+        uri
+      }
+`
+
+	querySmartFolderAttachmentList = `
+query smartFolderAttachmentList($filter: [String!], $next_token: String, $attachment_filter: [String!]) {
+  resources(filter: $filter, paging: $next_token) {
+    items {
+      attachedResources(filter: $attachment_filter) {` + smartFolderAttachmentItemFields + `      }` + smartFolderAttachmentSelfFields + `    }
+    paging {
+      next
+    }
+  }
+}
+`
+
+	queryResourceSmartFolderAttachmentList = `
+query resourceSmartFolderAttachmentList($filter: [String!], $next_token: String, $attachment_filter: [String!]) {
+  resources(filter: $filter, paging: $next_token) {
+    items {
+      attachedSmartFolders(filter: $attachment_filter) {` + smartFolderAttachmentItemFields + `      }` + smartFolderAttachmentSelfFields + `    }
+    paging {
+      next
+    }
+  }
+}
+`
+
+	// Further pages of the attachments of a single resource
+	querySmartFolderAttachedResources = `
+query smartFolderAttachedResources($id: ID!, $attachment_filter: [String!], $attachment_next_token: String) {
+  resource(id: $id) {
+    attachedResources(filter: $attachment_filter, paging: $attachment_next_token) {` + smartFolderAttachmentItemFields + `    }
+  }
+}
+`
+
+	queryResourceAttachedSmartFolders = `
+query resourceAttachedSmartFolders($id: ID!, $attachment_filter: [String!], $attachment_next_token: String) {
+  resource(id: $id) {
+    attachedSmartFolders(filter: $attachment_filter, paging: $attachment_next_token) {` + smartFolderAttachmentItemFields + `    }
+  }
+}
+`
+)
+
+func listSmartFolderAttachment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_smart_folder_attachment.listSmartFolderAttachment", "connection_error", err)
+		return nil, err
+	}
+
+	quals := d.EqualsQuals
+	effective := quals["effective"] != nil && quals["effective"].GetBoolValue()
+
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+
+	// When the resource is known, start from the resource and walk its attached
+	// smart folders rather than scanning every smart folder in the workspace.
+	query := querySmartFolderAttachmentList
+	pageQuery := querySmartFolderAttachedResources
+	filters := []string{"resourceTypeId:'tmod:@turbot/turbot#/resource/types/smartFolder' resourceTypeLevel:self"}
+	fromResource := quals["resource_id"] != nil
+
+	// For effective attachments of a resource, the smart folders attached to
+	// its ancestors govern it too, so walk the smart folders of its whole path
+	var governed map[string][]SmartFolderAttachmentItem
+	if fromResource && effective {
+		governed, err = listSmartFolderGovernedResources(conn, getQualListValues(ctx, quals, "resource_id", "int64"))
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_smart_folder_attachment.listSmartFolderAttachment", "query_error", err)
+			return nil, err
+		}
+		// None of the resources exist
+		if len(governed) == 0 {
+			return nil, nil
+		}
+	}
+
+	if fromResource {
+		query = queryResourceSmartFolderAttachmentList
+		pageQuery = queryResourceAttachedSmartFolders
+		resourceIds := getQualListValues(ctx, quals, "resource_id", "int64")
+		if effective {
+			resourceIds = strings.Join(slices.Collect(maps.Keys(governed)), ",")
+		}
+		filters = []string{fmt.Sprintf("resourceId:%s level:self", resourceIds)}
+	} else if quals["smart_folder_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "smart_folder_id", "int64")))
+	}
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+	attachmentFilter := []string{fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit)))}
+
+	plugin.Logger(ctx).Debug("guardrails_smart_folder_attachment.listSmartFolderAttachment", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":            filters,
+		"next_token":        "",
+		"attachment_filter": attachmentFilter,
+	}
+
+	for {
+		result := &SmartFolderAttachmentsResponse{}
+		err = conn.DoRequest(query, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_smart_folder_attachment.listSmartFolderAttachment", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.Resources.Items {
+			self := SmartFolderAttachmentItem{Trunk: r.Trunk, Turbot: r.Turbot, Type: r.Type}
+			attachments := r.AttachedResources
+			if fromResource {
+				attachments = r.AttachedSmartFolders
+			}
+
+			// Page through all the attachments of the resource, not just the
+			// first page returned with it
+			for {
+				for _, attached := range attachments.Items {
+					switch {
+					case fromResource && effective:
+						// One row for each requested resource at or below the
+						// resource the smart folder is attached to
+						for _, resource := range governed[r.Turbot.ID] {
+							d.StreamListItem(ctx, SmartFolderAttachment{SmartFolder: attached, Resource: resource, AttachedResource: self})
+							if d.RowsRemaining(ctx) == 0 {
+								return nil, nil
+							}
+						}
+					case fromResource:
+						d.StreamListItem(ctx, SmartFolderAttachment{SmartFolder: attached, Resource: self, AttachedResource: self})
+					default:
+						d.StreamListItem(ctx, SmartFolderAttachment{SmartFolder: self, Resource: attached, AttachedResource: attached})
+						if effective {
+							err = streamSmartFolderDescendants(ctx, d, conn, self, attached, pageLimit)
+							if err != nil {
+								plugin.Logger(ctx).Error("guardrails_smart_folder_attachment.listSmartFolderAttachment", "query_error", err)
+								return nil, err
+							}
+						}
+					}
+
+					// Context can be cancelled due to manual cancellation or the limit has been hit
+					if d.RowsRemaining(ctx) == 0 {
+						return nil, nil
+					}
+				}
+				if attachments.Paging.Next == "" {
+					break
+				}
+
+				pageResult := &SmartFolderAttachmentResourceResponse{}
+				pageVariables := map[string]interface{}{
+					"id":                    r.Turbot.ID,
+					"attachment_filter":     attachmentFilter,
+					"attachment_next_token": attachments.Paging.Next,
+				}
+				err = conn.DoRequest(pageQuery, pageVariables, pageResult)
+				if err != nil {
+					plugin.Logger(ctx).Error("guardrails_smart_folder_attachment.listSmartFolderAttachment", "query_error", err)
+					return nil, err
+				}
+				attachments = pageResult.Resource.AttachedResources
+				if fromResource {
+					attachments = pageResult.Resource.AttachedSmartFolders
+				}
+			}
+		}
+		if result.Resources.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Resources.Paging.Next
+	}
+
+	return nil, nil
+}
+
+// streamSmartFolderDescendants streams a row for each descendant of a resource
+// the smart folder is attached to, since the smart folder governs them too
+func streamSmartFolderDescendants(ctx context.Context, d *plugin.QueryData, conn *apiClient.Client, smartFolder SmartFolderAttachmentItem, attached SmartFolderAttachmentItem, pageLimit int64) error {
+	variables := map[string]interface{}{
+		"filter":     []string{fmt.Sprintf("resourceId:%s level:descendant", attached.Turbot.ID), fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit)))},
+		"next_token": "",
+	}
+
+	for {
+		result := &ResourcesResponse{}
+		err := conn.DoRequest(queryResourceHierarchy, variables, result)
+		if err != nil {
+			return err
+		}
+		for _, r := range result.Resources.Items {
+			// Skip the attached resource itself
+			if r.Turbot.ID == attached.Turbot.ID {
+				continue
+			}
+
+			d.StreamListItem(ctx, SmartFolderAttachment{SmartFolder: smartFolder, Resource: smartFolderAttachmentItemFromResource(r), AttachedResource: attached})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+		if result.Resources.Paging.Next == "" {
+			return nil
+		}
+		variables["next_token"] = result.Resources.Paging.Next
+	}
+}
+
+// listSmartFolderGovernedResources looks up the given resources and returns
+// them keyed by the id of each resource in their paths, i.e. the resources a
+// smart folder attached to that id governs
+func listSmartFolderGovernedResources(conn *apiClient.Client, resourceIds string) (map[string][]SmartFolderAttachmentItem, error) {
+	variables := map[string]interface{}{
+		"filter":     []string{fmt.Sprintf("resourceId:%s level:self", resourceIds), "limit:5000"},
+		"next_token": "",
+	}
+
+	governed := map[string][]SmartFolderAttachmentItem{}
+	for {
+		result := &ResourcesResponse{}
+		err := conn.DoRequest(queryResourceHierarchy, variables, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range result.Resources.Items {
+			item := smartFolderAttachmentItemFromResource(r)
+			for _, id := range strings.Split(r.Turbot.Path, ".") {
+				governed[id] = append(governed[id], item)
+			}
+		}
+		if result.Resources.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Resources.Paging.Next
+	}
+
+	return governed, nil
+}
+
+func smartFolderAttachmentItemFromResource(r Resource) SmartFolderAttachmentItem {
+	item := SmartFolderAttachmentItem{Trunk: r.Trunk, Type: r.Type}
+	item.Turbot.ID = r.Turbot.ID
+	item.Turbot.Title = r.Turbot.Title
+	return item
+}
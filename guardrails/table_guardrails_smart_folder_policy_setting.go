@@ -0,0 +1,123 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsSmartFolderPolicySetting(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_smart_folder_policy_setting",
+		Description: "Policy settings defined on smart folders in the Turbot Guardrails workspace.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "smart_folder_id", Require: plugin.Optional},
+				{Name: "policy_type_id", Require: plugin.Optional},
+				{Name: "policy_type_uri", Require: plugin.Optional},
+			},
+			Hydrate: listSmartFolderPolicySetting,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the policy setting.", Hydrate: policySettingHydrateId},
+			{Name: "smart_folder_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the smart folder this policy setting is defined on.", Hydrate: policySettingHydrateResourceId},
+			{Name: "smart_folder_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the smart folder.", Hydrate: policySettingHydrateResourceTrunkTitle},
+			{Name: "precedence", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Precedence of the setting: REQUIRED or RECOMMENDED.", Hydrate: policySettingHydratePrecedence},
+			{Name: "policy_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the policy type for this policy setting.", Hydrate: policySettingHydratePolicyTypeUri},
+			{Name: "policy_type_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the policy type.", Hydrate: policySettingHydratePolicyTypeTrunkTitle},
+			{Name: "value", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Value of the policy setting (for non-calculated policy settings).", Hydrate: policySettingHydrateValue},
+			{Name: "is_calculated", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if this is a policy setting will be calculated for each value.", Hydrate: policySettingHydrateIsCalculated},
+			{Name: "note", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Optional note or comment for the setting.", Hydrate: policySettingHydrateNote},
+			// Other columns
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the policy setting was first discovered by Turbot. (It may have been created earlier.)", Hydrate: policySettingHydrateCreateTimestamp},
+			{Name: "input", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "For calculated policy settings, this is the input GraphQL query.", Hydrate: policySettingHydrateInput},
+			{Name: "policy_type_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the policy type for this policy setting.", Hydrate: policySettingHydratePolicyTypeId},
+			{Name: "template", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "For a calculated policy setting, this is the nunjucks template string defining a YAML string which is parsed to get the value.", Hydrate: policySettingHydrateTemplate},
+			{Name: "template_input", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "For calculated policy settings, this GraphQL query is run and used as input to the template.", Hydrate: policySettingHydrateTemplateInput},
+			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the policy setting was last modified (created, updated or deleted).", Hydrate: policySettingHydrateTimestamp},
+			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the policy setting was last updated in Turbot.", Hydrate: policySettingHydrateUpdateTimestamp},
+			{Name: "valid_from_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the policy setting becomes valid.", Hydrate: policySettingHydrateValidFromTimestamp},
+			{Name: "valid_to_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Timestamp when the policy setting expires.", Hydrate: policySettingHydrateValidToTimestamp},
+			{Name: "value_source", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "The raw value in YAML format. If the setting was made via YAML template including comments, these will be included here.", Hydrate: policySettingHydrateValueSource},
+			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier for this version of the policy setting.", Hydrate: policySettingHydrateVersionId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+func listSmartFolderPolicySetting(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_smart_folder_policy_setting.listSmartFolderPolicySetting", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{"resourceTypeId:'tmod:@turbot/turbot#/resource/types/smartFolder' resourceTypeLevel:self"}
+	quals := d.EqualsQuals
+
+	// Additional filters
+	if quals["smart_folder_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s level:self", getQualListValues(ctx, quals, "smart_folder_id", "int64")))
+	}
+
+	if quals["policy_type_id"] != nil {
+		filters = append(filters, fmt.Sprintf("policyTypeId:%s policyTypeLevel:self", getQualListValues(ctx, quals, "policy_type_id", "int64")))
+	}
+
+	if quals["policy_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("policyTypeId:%s policyTypeLevel:self", getQualListValues(ctx, quals, "policy_type_uri", "string")))
+	}
+
+	// Setting a high limit and page all results
+	var pageLimit int64 = 5000
+
+	// Adjust page limit, if less than default value
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < pageLimit {
+			pageLimit = *limit
+		}
+	}
+
+	// Setting page limit
+	filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+
+	plugin.Logger(ctx).Debug("guardrails_smart_folder_policy_setting.listSmartFolderPolicySetting", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	// The policy setting query is shared with guardrails_policy_setting, so map
+	// the smart folder columns onto their policy setting equivalents.
+	appendPolicySettingColumnIncludes(&variables, smartFolderPolicySettingColumns(d.QueryContext.Columns))
+
+	for {
+		result := &PolicySettingsResponse{}
+		err = conn.DoRequest(queryPolicySettingList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_smart_folder_policy_setting.listSmartFolderPolicySetting", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.PolicySettings.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if result.PolicySettings.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.PolicySettings.Paging.Next
+	}
+
+	return nil, nil
+}
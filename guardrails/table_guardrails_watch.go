@@ -0,0 +1,160 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsWatch(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_watch",
+		Description: "Watches subscribe resources in the Turbot Guardrails workspace to real-time events.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "id", Require: plugin.Optional},
+				{Name: "action", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_type_uri", Require: plugin.Optional},
+				{Name: "filter", Require: plugin.Optional},
+			},
+			Hydrate: listWatch,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier of the watch.", Hydrate: watchHydrateId},
+			{Name: "resource_trunk_title", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Full title (including ancestor trunk) of the watched resource.", Hydrate: watchHydrateResourceTrunkTitle},
+			{Name: "action", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Action taken when a matching event is received.", Hydrate: watchHydrateAction},
+			{Name: "filters", Type: proto.ColumnType_JSON, Transform: transform.FromValue(), Description: "Filters an event must match to trigger the watch.", Hydrate: watchHydrateFilters},
+			{Name: "is_favorite", Type: proto.ColumnType_BOOL, Transform: transform.FromValue(), Description: "True if the watch is marked as a favorite.", Hydrate: watchHydrateIsFavorite},
+			// Other columns
+			{Name: "create_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the watch was created.", Hydrate: watchHydrateCreateTimestamp},
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "Filter used for this watch list."},
+			{Name: "resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the watched resource.", Hydrate: watchHydrateResourceId},
+			{Name: "resource_type_uri", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "URI of the resource type of the watched resource.", Hydrate: watchHydrateResourceTypeUri},
+			{Name: "update_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "When the watch was last updated.", Hydrate: watchHydrateUpdateTimestamp},
+			{Name: "version_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Unique identifier for this version of the watch.", Hydrate: watchHydrateVersionId},
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+		},
+	}
+}
+
+const (
+	queryWatchList = `
+query watchList($filter: [String!], $next_token: String) {
+  watches(filter: $filter, paging: $next_token) {
+    items {
+      action
+      favorite
+      filters
+      resource {
+        trunk {
+          title
+        }
+        type {
+          uri
+        }
+      }
+      turbot {
+        id
+        createTimestamp
+        resourceId
+        updateTimestamp
+        versionId
+      }
+    }
+    paging {
+      next
+    }
+  }
+}
+`
+)
+
+func listWatch(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_watch.listWatch", "connection_error", err)
+		return nil, err
+	}
+
+	filters := []string{}
+	quals := d.EqualsQuals
+
+	filter := ""
+	if quals["filter"] != nil {
+		filter = quals["filter"].GetStringValue()
+		filters = append(filters, filter)
+	}
+
+	// Additional filters
+	if quals["id"] != nil {
+		filters = append(filters, fmt.Sprintf("id:%s", getQualListValues(ctx, quals, "id", "int64")))
+	}
+	if quals["action"] != nil {
+		filters = append(filters, fmt.Sprintf("action:%s", getQualListValues(ctx, quals, "action", "string")))
+	}
+	if quals["resource_id"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceId:%s", getQualListValues(ctx, quals, "resource_id", "int64")))
+	}
+	if quals["resource_type_uri"] != nil {
+		filters = append(filters, fmt.Sprintf("resourceTypeId:%s resourceTypeLevel:self", getQualListValues(ctx, quals, "resource_type_uri", "string")))
+	}
+
+	// Default to a very large page size. Page sizes earlier in the filter string
+	// win, so this is only used as a fallback.
+	pageResults := false
+	// Add a limit if they haven't given one in the filter field
+	re := regexp.MustCompile(`(^|\s)limit:[0-9]+($|\s)`)
+	if !re.MatchString(filter) {
+		// The caller did not specify a limit, so set a high limit and page all
+		// results.
+		pageResults = true
+		var pageLimit int64 = 5000
+
+		// Adjust page limit, if less than default value
+		limit := d.QueryContext.Limit
+		if d.QueryContext.Limit != nil {
+			if *limit < pageLimit {
+				pageLimit = *limit
+			}
+		}
+		filters = append(filters, fmt.Sprintf("limit:%s", strconv.Itoa(int(pageLimit))))
+	}
+
+	plugin.Logger(ctx).Debug("guardrails_watch.listWatch", "quals", quals)
+	plugin.Logger(ctx).Debug("guardrails_watch.listWatch", "filters", filters)
+
+	variables := map[string]interface{}{
+		"filter":     filters,
+		"next_token": "",
+	}
+
+	for {
+		result := &WatchesResponse{}
+		err = conn.DoRequest(queryWatchList, variables, result)
+		if err != nil {
+			plugin.Logger(ctx).Error("guardrails_watch.listWatch", "query_error", err)
+			return nil, err
+		}
+		for _, r := range result.Watches.Items {
+			d.StreamListItem(ctx, r)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		if !pageResults || result.Watches.Paging.Next == "" {
+			break
+		}
+		variables["next_token"] = result.Watches.Paging.Next
+	}
+
+	return nil, nil
+}
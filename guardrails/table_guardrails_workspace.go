@@ -0,0 +1,151 @@
+package turbot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableGuardrailsWorkspace(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "guardrails_workspace",
+		Description: "Settings of the Turbot Guardrails workspace, including its version, installed mods and Turbot level policy values.",
+		List: &plugin.ListConfig{
+			Hydrate: listWorkspace,
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "workspace", Type: proto.ColumnType_STRING, Hydrate: getTurbotGuardrailsWorkspace, Transform: transform.FromValue(), Description: "Specifies the workspace URL."},
+			{Name: "workspace_version", Type: proto.ColumnType_STRING, Transform: transform.FromValue().NullIfZero(), Description: "Version of the Turbot Guardrails workspace, or null if it can't be read.", Hydrate: workspaceHydrateVersion},
+			{Name: "turbot_mod_version", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Version of the installed @turbot/turbot mod.", Hydrate: workspaceHydrateTurbotModVersion},
+			{Name: "mod_count", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "Number of mods installed in the workspace.", Hydrate: workspaceHydrateModCount},
+			// Other columns
+			{Name: "root_resource_id", Type: proto.ColumnType_INT, Transform: transform.FromValue(), Description: "ID of the Turbot root resource of the workspace.", Hydrate: workspaceHydrateRootResourceId},
+			{Name: "time_zone", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Value of the Turbot > Time Zone policy.", Hydrate: workspaceHydrateTimeZone},
+			{Name: "workspace_url", Type: proto.ColumnType_STRING, Transform: transform.FromValue(), Description: "Value of the Turbot > Workspace URL policy.", Hydrate: workspaceHydrateWorkspaceUrl},
+		},
+	}
+}
+
+type Workspace struct {
+	Version  string
+	Settings WorkspaceResponse
+}
+
+const (
+	queryWorkspace = `
+query workspace {
+  root: resource(id: "tmod:@turbot/turbot#/") {
+    turbot {
+      id
+    }
+  }
+  turbotMod: resource(id: "tmod:@turbot/turbot") {
+    version: get(path: "version")
+  }
+  mods: resources(filter: "resourceTypeId:'tmod:@turbot/turbot#/resource/types/mod' resourceTypeLevel:self limit:0") {
+    metadata {
+      stats {
+        total
+      }
+    }
+  }
+  workspaceUrl: policyValue(uri: "tmod:@turbot/turbot#/policy/types/workspaceUrl", resourceId: "tmod:@turbot/turbot#/") {
+    value
+  }
+  timeZone: policyValue(uri: "tmod:@turbot/turbot#/policy/types/timeZone", resourceId: "tmod:@turbot/turbot#/") {
+    value
+  }
+}
+`
+)
+
+func listWorkspace(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_workspace.listWorkspace", "connection_error", err)
+		return nil, err
+	}
+
+	workspace := Workspace{}
+
+	// The version comes from a separate request, so failing to read it only
+	// leaves the column null
+	if slices.Contains(d.QueryContext.Columns, "workspace_version") {
+		version, err := getWorkspaceVersion(ctx, d)
+		if err != nil {
+			plugin.Logger(ctx).Warn("guardrails_workspace.listWorkspace", "workspace_version_error", err)
+		} else {
+			workspace.Version = version.String()
+		}
+	}
+
+	err = conn.DoRequest(queryWorkspace, nil, &workspace.Settings)
+	if err != nil {
+		plugin.Logger(ctx).Error("guardrails_workspace.listWorkspace", "query_error", err)
+		return nil, err
+	}
+
+	d.StreamListItem(ctx, workspace)
+	return nil, nil
+}
+
+func extractWorkspaceFromHydrateItem(h *plugin.HydrateData) (Workspace, error) {
+	if workspace, ok := h.Item.(Workspace); ok {
+		return workspace, nil
+	} else {
+		return Workspace{}, fmt.Errorf("unable to parse hydrate item %v as a Workspace", h.Item)
+	}
+}
+
+func workspaceHydrateVersion(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	workspace, err := extractWorkspaceFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	return workspace.Version, nil
+}
+
+func workspaceHydrateTurbotModVersion(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	workspace, err := extractWorkspaceFromHydrateItem(h)
+	if err != nil || workspace.Settings.TurbotMod == nil {
+		return nil, err
+	}
+	return workspace.Settings.TurbotMod.Version, nil
+}
+
+func workspaceHydrateModCount(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	workspace, err := extractWorkspaceFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	return workspace.Settings.Mods.Metadata.Stats.Total, nil
+}
+
+func workspaceHydrateRootResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	workspace, err := extractWorkspaceFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	return workspace.Settings.Root.Turbot.ID, nil
+}
+
+func workspaceHydrateTimeZone(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	workspace, err := extractWorkspaceFromHydrateItem(h)
+	if err != nil || workspace.Settings.TimeZone == nil {
+		return nil, err
+	}
+	return workspace.Settings.TimeZone.Value, nil
+}
+
+func workspaceHydrateWorkspaceUrl(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	workspace, err := extractWorkspaceFromHydrateItem(h)
+	if err != nil || workspace.Settings.WorkspaceURL == nil {
+		return nil, err
+	}
+	return workspace.Settings.WorkspaceURL.Value, nil
+}
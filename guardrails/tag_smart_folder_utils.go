@@ -3,9 +3,11 @@ package turbot
 import (
     "context"
     "fmt"
+    "maps"
     "slices"
 
     "github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+    "github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func appendSmartFolderColumnIncludes(m *map[string]interface{}, cols []string) {
@@ -163,6 +165,140 @@ func smartFolderHydrateAttachedResources(ctx context.Context, d *plugin.QueryDat
     return resource.AttachedResources.Items, nil
 }
 
+func extractSmartFolderAttachmentFromHydrateItem(h *plugin.HydrateData) (SmartFolderAttachment, error) {
+    if attachment, ok := h.Item.(SmartFolderAttachment); ok {
+        return attachment, nil
+    } else {
+        return SmartFolderAttachment{}, fmt.Errorf("unable to parse hydrate item %v as a SmartFolderAttachment", h.Item)
+    }
+}
+
+func smartFolderAttachmentHydrateSmartFolderId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.SmartFolder.Turbot.ID, nil
+}
+
+func smartFolderAttachmentHydrateSmartFolderTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.SmartFolder.Turbot.Title, nil
+}
+
+func smartFolderAttachmentHydrateSmartFolderTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.SmartFolder.Trunk.Title, nil
+}
+
+func smartFolderAttachmentHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.Resource.Turbot.ID, nil
+}
+
+func smartFolderAttachmentHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.Resource.Trunk.Title, nil
+}
+
+func smartFolderAttachmentHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.Resource.Type.URI, nil
+}
+
+func smartFolderAttachmentHydrateAttachedResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.AttachedResource.Turbot.ID, nil
+}
+
+func smartFolderAttachmentHydrateAttachedResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    attachment, err := extractSmartFolderAttachmentFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return attachment.AttachedResource.Trunk.Title, nil
+}
+
+// matchSmartFolderTag returns the first tag matching one of the tag_key and
+// tag_value quals. Empty quals match any tag.
+func matchSmartFolderTag(tags map[string]interface{}, keys []string, values []string) (string, string, bool) {
+    for _, k := range slices.Sorted(maps.Keys(tags)) {
+        v := fmt.Sprint(tags[k])
+        if len(keys) > 0 && !slices.Contains(keys, k) {
+            continue
+        }
+        if len(values) > 0 && !slices.Contains(values, v) {
+            continue
+        }
+        return k, v, true
+    }
+    return "", "", false
+}
+
+// smartFolderMatchesTag reports whether the smart folder has a tag matching the
+// tag_key and tag_value quals. Empty quals match everything.
+func smartFolderMatchesTag(r Resource, keys []string, values []string) bool {
+    if len(keys) == 0 && len(values) == 0 {
+        return true
+    }
+    _, _, ok := matchSmartFolderTag(r.Turbot.Tags, keys, values)
+    return ok
+}
+
+// smartFolderMatchedTag populates tag_key and tag_value from the tag that
+// matched the quals, so rows also satisfy in (...) lists
+func smartFolderMatchedTag(_ context.Context, d *transform.TransformData) (interface{}, error) {
+    keys := qualSliceStringValues(d.KeyColumnQuals["tag_key"])
+    values := qualSliceStringValues(d.KeyColumnQuals["tag_value"])
+    if len(keys) == 0 && len(values) == 0 {
+        return nil, nil
+    }
+    tags, _ := d.Value.(map[string]interface{})
+    k, v, ok := matchSmartFolderTag(tags, keys, values)
+    if !ok {
+        return nil, nil
+    }
+    if d.Param.(string) == "key" {
+        return k, nil
+    }
+    return v, nil
+}
+
+// smartFolderPolicySettingColumns maps the columns of guardrails_smart_folder_policy_setting
+// onto the guardrails_policy_setting columns used by appendPolicySettingColumnIncludes
+func smartFolderPolicySettingColumns(cols []string) []string {
+    mapped := []string{}
+    for _, c := range cols {
+        switch c {
+        case "smart_folder_id":
+            mapped = append(mapped, "resource_id")
+        case "smart_folder_trunk_title":
+            mapped = append(mapped, "resource_trunk_title")
+        default:
+            mapped = append(mapped, c)
+        }
+    }
+    return mapped
+}
+
 func appendTagColumnIncludes(m *map[string]interface{}, cols []string) {
     (*m)["includeTagKey"] = slices.Contains(cols, "key")
     (*m)["includeTagValue"] = slices.Contains(cols, "value")
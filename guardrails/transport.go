@@ -0,0 +1,95 @@
+package turbot
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-guardrails/apiClient"
+)
+
+// buildTransport builds the HTTP transport for the Guardrails API from the
+// connection config and the credentials profile in use. Connection settings
+// take precedence over the profile settings. Unset options keep the
+// http.DefaultTransport behavior, including proxies from the environment.
+func buildTransport(guardrailsConfig guardrailsConfig, profile *apiClient.CredentialsProfile) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// TLS
+	tlsConfig := &tls.Config{}
+	if profile != nil {
+		tlsConfig.InsecureSkipVerify = profile.InsecureSkipVerify
+	}
+	if guardrailsConfig.InsecureSkipVerify != nil {
+		tlsConfig.InsecureSkipVerify = *guardrailsConfig.InsecureSkipVerify
+	}
+	if guardrailsConfig.CACertFile != nil {
+		caCert, err := os.ReadFile(*guardrailsConfig.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+		}
+		// Trust the internal CA in addition to the system roots
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no PEM certificates found in ca_cert_file %s", *guardrailsConfig.CACertFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if guardrailsConfig.ClientCertFile != nil || guardrailsConfig.ClientKeyFile != nil {
+		if guardrailsConfig.ClientCertFile == nil || guardrailsConfig.ClientKeyFile == nil {
+			return nil, fmt.Errorf("client_cert_file and client_key_file must be set together")
+		}
+		clientCert, err := tls.LoadX509KeyPair(*guardrailsConfig.ClientCertFile, *guardrailsConfig.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	// Proxy
+	proxy := ""
+	if profile != nil {
+		proxy = profile.Proxy
+	}
+	if guardrailsConfig.ProxyUrl != nil {
+		proxy = *guardrailsConfig.ProxyUrl
+	}
+	if proxy != "" {
+		proxyUrl, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %s: %w", proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	// Connection pool and keep-alive
+	if guardrailsConfig.MaxIdleConnections != nil {
+		transport.MaxIdleConns = *guardrailsConfig.MaxIdleConnections
+		transport.MaxIdleConnsPerHost = *guardrailsConfig.MaxIdleConnections
+	}
+	if guardrailsConfig.MaxConnectionsPerHost != nil {
+		transport.MaxConnsPerHost = *guardrailsConfig.MaxConnectionsPerHost
+	}
+	if guardrailsConfig.IdleConnectionTimeout != nil {
+		transport.IdleConnTimeout = time.Duration(*guardrailsConfig.IdleConnectionTimeout) * time.Second
+	}
+	if guardrailsConfig.KeepAliveInterval != nil {
+		// TCP keep-alive probe interval, a negative interval disables the probes
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: time.Duration(*guardrailsConfig.KeepAliveInterval) * time.Second,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return transport, nil
+}
@@ -37,6 +37,54 @@ type Resource struct {
 	}
 }
 
+type SmartFolderAttachmentsResponse struct {
+	Resources struct {
+		Items  []SmartFolderAttachmentResource
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type SmartFolderAttachmentResourceResponse struct {
+	Resource SmartFolderAttachmentResource
+}
+
+type SmartFolderAttachmentResource struct {
+	AttachedResources    SmartFolderAttachmentItems
+	AttachedSmartFolders SmartFolderAttachmentItems
+	Trunk                struct {
+		Title string
+	}
+	Turbot struct {
+		ID    string
+		Title string
+	}
+	Type struct {
+		URI string
+	}
+}
+
+type SmartFolderAttachmentItems struct {
+	Items  []SmartFolderAttachmentItem
+	Paging struct {
+		Next string
+	}
+}
+
+type SmartFolderAttachmentItem struct {
+	Trunk struct {
+		Title string
+	}
+	Turbot struct {
+		ID    string
+		Title string
+	}
+	Type struct {
+		URI string
+	}
+}
+
 type ResourceTypesResponse struct {
 	ResourceTypes struct {
 		Items  []ResourceType
@@ -183,7 +231,25 @@ type Grant struct {
 			Title string
 		}
 	}
-	Turbot GuardrailsControlMetadata
+	Turbot             GuardrailsControlMetadata
+	ValidFromTimestamp *string
+	ValidToTimestamp   *string
+	// Not part of the grant query, filled in from the active grants when needed
+	ActiveGrantResourceIDs []string
+}
+
+type GrantActivationsResponse struct {
+	ActiveGrants struct {
+		Items []struct {
+			Turbot struct {
+				GrantID    string
+				ResourceID string
+			}
+		}
+		Paging struct {
+			Next string
+		}
+	}
 }
 
 type PolicyTypesResponse struct {
@@ -251,10 +317,24 @@ type PolicyValue struct {
 	Turbot                PolicyValueTurbotProperty
 }
 
+type PolicyValueExplainResponse struct {
+	Resource struct {
+		Turbot struct {
+			Path string
+		}
+	}
+	PolicyValues struct {
+		Items []PolicyValue
+	}
+}
+
 type PolicyValueResourceDetails struct {
 	Trunk struct {
 		Title string
 	}
+	Turbot struct {
+		Akas []string
+	}
 }
 
 type PolicyValueType struct {
@@ -324,6 +404,9 @@ type Control struct {
 		Trunk struct {
 			Title string
 		}
+		Turbot struct {
+			Akas []string
+		}
 	}
 	Type struct {
 		Trunk struct {
@@ -608,6 +691,69 @@ type ActiveGrant struct {
 	Turbot TurbotResourceMetadata
 }
 
+type EffectivePermissionGrantsResponse struct {
+	ActiveGrants struct {
+		Items  []EffectivePermissionGrant
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type EffectivePermissionGrant struct {
+	Resource struct {
+		Trunk struct {
+			Title string
+		}
+		Turbot struct {
+			ID string
+		}
+	}
+	Grant struct {
+		Identity EffectivePermissionIdentity
+		Level    struct {
+			Title string
+			URI   string
+		}
+		Turbot struct {
+			ID string
+		}
+	}
+}
+
+type EffectivePermissionIdentity struct {
+	Email     string
+	ProfileID string
+	// Groups a user profile is a member of, stored as strings or numbers
+	GroupProfileIDs []interface{}
+	Trunk           struct {
+		Title string
+	}
+	Turbot struct {
+		ID string
+	}
+	Type struct {
+		URI string
+	}
+}
+
+type EffectivePermissionProfilesResponse struct {
+	Resources struct {
+		Items  []EffectivePermissionIdentity
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type ResourcePathResponse struct {
+	Resource struct {
+		Turbot struct {
+			Path string
+		}
+	}
+}
+
 type GrantNotification struct {
 	RoleName           *string
 	PermissionTypeID   *string
@@ -633,3 +779,391 @@ type ControlMetadataResponse struct {
 		Metadata interface{}
 	}
 }
+
+type ConnectionAccessKeyResponse struct {
+	Resources struct {
+		Items []struct {
+			Turbot struct {
+				ParentID string
+			}
+		}
+	}
+}
+
+type ConnectionIdentityResponse struct {
+	Resource ConnectionIdentity
+}
+
+type ConnectionIdentity struct {
+	Email string
+	Trunk struct {
+		Title string
+	}
+	Turbot struct {
+		ID string
+	}
+}
+
+type WorkspaceResponse struct {
+	Root struct {
+		Turbot struct {
+			ID string
+		}
+	}
+	TurbotMod *struct {
+		Version string
+	}
+	Mods struct {
+		Metadata struct {
+			Stats struct {
+				Total int64
+			}
+		}
+	}
+	WorkspaceURL *struct {
+		Value interface{}
+	}
+	TimeZone *struct {
+		Value interface{}
+	}
+}
+
+// ResourceHierarchyItem is an ancestor or descendant of a resource, with the
+// number of levels between them
+type ResourceHierarchyItem struct {
+	Depth    int
+	Resource Resource
+}
+
+type SummaryType struct {
+	URI   string
+	Trunk struct {
+		Title string
+	}
+	Turbot struct {
+		ID string
+	}
+}
+
+// ControlStateSummary is the number of controls in each state
+type ControlStateSummary struct {
+	Alarm   int64
+	Error   int64
+	Invalid int64
+	Ok      int64
+	Skipped int64
+	Tbd     int64
+	Total   int64
+}
+
+type ResourceSummariesResponse struct {
+	ResourceSummariesByResourceType struct {
+		Items  []ResourceSummary
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type ResourceSummary struct {
+	Type    SummaryType
+	Summary struct {
+		Resource struct {
+			Total int64
+		}
+	}
+}
+
+type ControlSummariesResponse struct {
+	ControlSummariesByControlType struct {
+		Items  []ControlSummary
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type ControlSummary struct {
+	Type    SummaryType
+	Summary struct {
+		Control ControlStateSummary
+	}
+}
+
+// ControlSummaryRow is the number of controls of a control type in one state
+type ControlSummaryRow struct {
+	Type  SummaryType
+	State string
+	Count int64
+}
+
+type ResourceControlSummariesResponse struct {
+	ControlSummariesByResource struct {
+		Items  []ResourceControlSummary
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type ResourceControlSummary struct {
+	Resource struct {
+		Trunk struct {
+			Title string
+		}
+		Turbot struct {
+			ID string
+		}
+		Type SummaryType
+	}
+	Summary struct {
+		Control ControlStateSummary
+	}
+}
+
+type ControlRunTargetsResponse struct {
+	Controls struct {
+		Items  []ControlRunTarget
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type ControlRunTarget struct {
+	Resource struct {
+		Trunk struct {
+			Title string
+		}
+	}
+	Turbot struct {
+		ID         string
+		ResourceID string
+	}
+}
+
+type ActionTypesResponse struct {
+	ActionTypes struct {
+		Items  []ActionType
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type ActionTypeResponse struct {
+	ActionType ActionType
+}
+
+type ActionType struct {
+	Description     string
+	Icon            string
+	ModURI          string
+	PermissionType  *string
+	PermissionLevel *string
+	Targets         []string
+	Title           string
+	Trunk           struct {
+		Title string
+	}
+	Turbot TurbotResourceMetadata
+	URI    string
+}
+
+type ActionsResponse struct {
+	Notifications struct {
+		Items  []Action
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+// Action is an action notification, recorded each time an action is run
+type Action struct {
+	Message string
+	Actor   struct {
+		Identity struct {
+			Trunk struct {
+				Title string
+			}
+			Turbot struct {
+				ID string
+			}
+		}
+	}
+	Action struct {
+		State  string
+		Reason string
+		Type   struct {
+			URI   string
+			Trunk struct {
+				Title string
+			}
+		}
+	}
+	Resource struct {
+		Trunk struct {
+			Title string
+		}
+		Type struct {
+			URI string
+		}
+	}
+	Turbot struct {
+		ID              string
+		CreateTimestamp *string
+		ControlID       *string
+		ProcessID       *string
+		ResourceID      *string
+	}
+}
+
+type ControlCategoriesResponse struct {
+	ControlCategories struct {
+		Items  []ControlCategory
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type ControlCategoryResponse struct {
+	ControlCategory ControlCategory
+}
+
+// ControlCategory groups both control types and policy types
+type ControlCategory struct {
+	Description string
+	Title       string
+	Trunk       struct {
+		Title string
+	}
+	Turbot TurbotResourceMetadata
+	URI    string
+}
+
+type PermissionTypesResponse struct {
+	PermissionTypes struct {
+		Items  []PermissionDefinition
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type PermissionLevelsResponse struct {
+	PermissionLevels struct {
+		Items  []PermissionDefinition
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+// PermissionDefinition is a permission type or a permission level
+type PermissionDefinition struct {
+	Description string
+	ModURI      string
+	// Rank is worked out from the level hierarchy, it isn't returned by Guardrails
+	Rank  *int
+	Title string
+	Trunk struct {
+		Title string
+	}
+	Turbot TurbotResourceMetadata
+	URI    string
+}
+
+type WatchesResponse struct {
+	Watches struct {
+		Items  []Watch
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+// Watch subscribes a resource to real-time events
+type Watch struct {
+	Action   string
+	Favorite bool
+	Filters  []string
+	Resource struct {
+		Trunk struct {
+			Title string
+		}
+		Type struct {
+			URI string
+		}
+	}
+	Turbot struct {
+		ID              string
+		CreateTimestamp string
+		ResourceID      string
+		UpdateTimestamp *string
+		VersionID       string
+	}
+}
+
+type EventsResponse struct {
+	Events struct {
+		Items  []Event
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+// Event is a real-time event received from a cloud provider
+type Event struct {
+	Source   string
+	Type     string
+	Resource struct {
+		Trunk struct {
+			Title string
+		}
+		Type struct {
+			URI string
+		}
+	}
+	Turbot struct {
+		ID              string
+		CreateTimestamp string
+		ProcessID       *string
+		ResourceID      *string
+	}
+}
+
+type FavoritesResponse struct {
+	Favorites struct {
+		Items  []Favorite
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+// Favorite is a resource favorited by an identity in the console
+type Favorite struct {
+	Identity struct {
+		ProfileID string
+		Trunk     struct {
+			Title string
+		}
+	}
+	Resource struct {
+		Trunk struct {
+			Title string
+		}
+		Type struct {
+			URI string
+		}
+	}
+	Turbot struct {
+		ID              string
+		CreateTimestamp string
+		IdentityID      string
+		ResourceID      string
+	}
+}
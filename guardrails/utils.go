@@ -2,11 +2,12 @@ package turbot
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/machinebox/graphql"
 	"github.com/turbot/go-kit/types"
@@ -15,6 +16,7 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
@@ -30,11 +32,55 @@ func connect(ctx context.Context, d *plugin.QueryData) (*apiClient.Client, error
 		return cachedData.(*apiClient.Client), nil
 	}
 
+	client, resolved, err := createClient(GetConfig(d.Connection))
+	if err != nil {
+		return nil, err
+	}
+	if err = client.Validate(); err != nil {
+		return nil, fmt.Errorf("Error validating Turbot Guardrails client for workspace %s using %s credentials: %s", resolved.Credentials.Workspace, resolved.Source, err.Error())
+	}
+
+	// Save to cache
+	d.ConnectionManager.Cache.Set(cacheKey, client)
+
+	// Done
+	return client, nil
+}
+
+// createClient creates an API client from the connection config, also
+// returning where the credentials were found
+func createClient(guardrailsConfig guardrailsConfig) (*apiClient.Client, apiClient.ResolvedCredentials, error) {
+	if err := validateConnectionConfig(guardrailsConfig); err != nil {
+		return nil, apiClient.ResolvedCredentials{}, fmt.Errorf("invalid Turbot Guardrails connection config: %w", err)
+	}
+
+	config := getClientConfig(guardrailsConfig)
+
+	resolved, err := apiClient.ResolveCredentials(config)
+	if err != nil {
+		return nil, resolved, fmt.Errorf("Error loading Turbot Guardrails credentials: %w", err)
+	}
+
+	// Settings from the credentials profile, if the credentials come from one
+	clientOptions, err := getClientOptions(guardrailsConfig, resolved.Profile)
+	if err != nil {
+		return nil, resolved, fmt.Errorf("Error creating HTTP client options: %w", err)
+	}
+
+	// Create the client
+	client, err := apiClient.CreateClient(config, clientOptions...)
+	if err != nil {
+		return nil, resolved, fmt.Errorf("Error creating Turbot Guardrails client: %s", err.Error())
+	}
+	return client, resolved, nil
+}
+
+// getClientConfig builds the API client config from the connection config
+func getClientConfig(guardrailsConfig guardrailsConfig) apiClient.ClientConfig {
 	// Start with an empty Turbot config
 	config := apiClient.ClientConfig{Credentials: apiClient.ClientCredentials{}}
 
 	// Prefer config options given in Steampipe
-	guardrailsConfig := GetConfig(d.Connection)
 	if guardrailsConfig.Profile != nil {
 		config.Profile = *guardrailsConfig.Profile
 	}
@@ -47,40 +93,29 @@ func connect(ctx context.Context, d *plugin.QueryData) (*apiClient.Client, error
 	if guardrailsConfig.SecretKey != nil {
 		config.Credentials.SecretKey = *guardrailsConfig.SecretKey
 	}
-
-	clientOptions, err := getClientOptions(guardrailsConfig)
-	if err != nil {
-		return nil, fmt.Errorf("Error creating HTTP client options: %w", err)
+	if guardrailsConfig.CredentialProcess != nil {
+		config.CredentialProcess = *guardrailsConfig.CredentialProcess
 	}
-
-	// Create the client
-	client, err := apiClient.CreateClient(config, clientOptions...)
-	if err != nil {
-		return nil, fmt.Errorf("Error creating Turbot Guardrails client: %s", err.Error())
+	if guardrailsConfig.AccessKeyFile != nil {
+		config.AccessKeyFile = *guardrailsConfig.AccessKeyFile
 	}
-	if err = client.Validate(); err != nil {
-		return nil, fmt.Errorf("Error validating Turbot Guardrails client: %s", err.Error())
+	if guardrailsConfig.SecretKeyFile != nil {
+		config.SecretKeyFile = *guardrailsConfig.SecretKeyFile
 	}
-
-	// Save to cache
-	d.ConnectionManager.Cache.Set(cacheKey, client)
-
-	// Done
-	return client, nil
+	return config
 }
 
-// getClientOptions returns the appropriate client options based on the guardrails configuration
-func getClientOptions(guardrailsConfig guardrailsConfig) ([]graphql.ClientOption, error) {
-	if guardrailsConfig.InsecureSkipVerify != nil && *guardrailsConfig.InsecureSkipVerify {
-		transport := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		clientWithOption := &http.Client{
-			Transport: transport,
-		}
-		return []graphql.ClientOption{graphql.WithHTTPClient(clientWithOption)}, nil
+// getClientOptions returns the appropriate client options based on the guardrails
+// configuration and the credentials profile in use
+func getClientOptions(guardrailsConfig guardrailsConfig, profile *apiClient.CredentialsProfile) ([]graphql.ClientOption, error) {
+	transport, err := buildTransport(guardrailsConfig, profile)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+	clientWithOption := &http.Client{
+		Transport: transport,
+	}
+	return []graphql.ClientOption{graphql.WithHTTPClient(clientWithOption)}, nil
 }
 
 func getMapValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
@@ -142,11 +177,14 @@ func pathToArray(_ context.Context, d *transform.TransformData) (interface{}, er
 	return pathInts, nil
 }
 
+// escapeFilterString escapes a value for use inside a quoted filter term
+func escapeFilterString(value string) string {
+	value = strings.Replace(value, "\\", "\\\\", -1)
+	return strings.Replace(value, "'", "\\'", -1)
+}
+
 func escapeQualString(_ context.Context, quals map[string]*proto.QualValue, qualName string) string {
-	s := quals[qualName].GetStringValue()
-	s = strings.Replace(s, "\\", "\\\\", -1)
-	s = strings.Replace(s, "'", "\\'", -1)
-	return s
+	return escapeFilterString(quals[qualName].GetStringValue())
 }
 
 // if the caching is required other than per connection, build a cache key for the call and use it in Memoize
@@ -170,23 +208,8 @@ func getTurbotGuardrailsWorkspace(ctx context.Context, d *plugin.QueryData, h *p
 
 func getTurbotGuardrailsWorkspaceUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 
-	// Start with an empty Turbot config
-	config := apiClient.ClientConfig{Credentials: apiClient.ClientCredentials{}}
-
-	// Prefer config options given in Steampipe
 	guardrailsConfig := GetConfig(d.Connection)
-	if guardrailsConfig.Profile != nil {
-		config.Profile = *guardrailsConfig.Profile
-	}
-	if guardrailsConfig.Workspace != nil {
-		config.Credentials.Workspace = *guardrailsConfig.Workspace
-	}
-	if guardrailsConfig.AccessKey != nil {
-		config.Credentials.AccessKey = *guardrailsConfig.AccessKey
-	}
-	if guardrailsConfig.SecretKey != nil {
-		config.Credentials.SecretKey = *guardrailsConfig.SecretKey
-	}
+	config := getClientConfig(guardrailsConfig)
 
 	credentials, err := apiClient.GetCredentials(config)
 	if err != nil {
@@ -230,3 +253,75 @@ func getQualListValues(ctx context.Context, quals map[string]*proto.QualValue, q
 	}
 	return ""
 }
+
+// qualStringValues returns the values of an equals qual on a string column,
+// whether it is a single value or an in (...) list
+func qualStringValues(q *proto.QualValue) []string {
+	if q == nil {
+		return nil
+	}
+	if list := q.GetListValue(); list != nil {
+		values := make([]string, 0, len(list.Values))
+		for _, v := range list.Values {
+			values = append(values, v.GetStringValue())
+		}
+		return values
+	}
+	return []string{q.GetStringValue()}
+}
+
+// qualSliceStringValues returns the values of the equals quals in the slice
+func qualSliceStringValues(qs quals.QualSlice) []string {
+	values := []string{}
+	for _, q := range qs {
+		if q.Operator == "=" {
+			values = append(values, qualStringValues(q.Value)...)
+		}
+	}
+	return values
+}
+
+// matchedAka populates an aka column from the resource akas in the value,
+// returning the one that matched the aka quals, so rows also satisfy in (...)
+// lists
+func matchedAka(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	want := qualSliceStringValues(d.KeyColumnQuals["aka"])
+	akas, _ := d.Value.([]string)
+	for _, aka := range akas {
+		if slices.Contains(want, aka) {
+			return aka, nil
+		}
+	}
+	return nil, nil
+}
+
+// filterSelectsResource reports whether a filter string already has a
+// resourceId term, which can't be combined with the one an aka qual adds
+func filterSelectsResource(filter string) bool {
+	for _, term := range strings.Fields(filter) {
+		if strings.HasPrefix(term, "resourceId:") {
+			return true
+		}
+	}
+	return false
+}
+
+// appendTimestampFilters converts the quals on a timestamp column to Guardrails
+// filters on the field, e.g. createTimestamp:>='2023-01-01T00:00:00.000Z'
+func appendTimestampFilters(allQuals plugin.KeyColumnQualMap, column string, field string, filters []string) []string {
+	if allQuals[column] == nil {
+		return filters
+	}
+	for _, q := range allQuals[column].Quals {
+		// Subtracted 1 minute to FilterFrom time and Added 1 minute to FilterTo time to miss any results due to time conersions in steampipe
+		switch q.Operator {
+		case "=":
+			filters = append(filters, fmt.Sprintf("%s:'%s'", field, q.Value.GetTimestampValue().AsTime().Format(filterTimeFormat)))
+		case ">=", ">":
+			filters = append(filters, fmt.Sprintf("%s:>='%s'", field, q.Value.GetTimestampValue().AsTime().Add(-1*time.Minute).Format(filterTimeFormat)))
+		case "<", "<=":
+			filters = append(filters, fmt.Sprintf("%s:<='%s'", field, q.Value.GetTimestampValue().AsTime().Add(1*time.Minute).Format(filterTimeFormat)))
+		}
+	}
+	return filters
+}
@@ -0,0 +1,168 @@
+package turbot
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func extractWatchFromHydrateItem(h *plugin.HydrateData) (Watch, error) {
+    if watch, ok := h.Item.(Watch); ok {
+        return watch, nil
+    } else {
+        return Watch{}, fmt.Errorf("unable to parse hydrate item %v as a Watch", h.Item)
+    }
+}
+
+func watchHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Turbot.ID, nil
+}
+
+func watchHydrateAction(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Action, nil
+}
+
+func watchHydrateFilters(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Filters, nil
+}
+
+func watchHydrateIsFavorite(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Favorite, nil
+}
+
+func watchHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Turbot.ResourceID, nil
+}
+
+func watchHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Resource.Trunk.Title, nil
+}
+
+func watchHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Resource.Type.URI, nil
+}
+
+func watchHydrateCreateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Turbot.CreateTimestamp, nil
+}
+
+func watchHydrateUpdateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Turbot.UpdateTimestamp, nil
+}
+
+func watchHydrateVersionId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    watch, err := extractWatchFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return watch.Turbot.VersionID, nil
+}
+
+func extractEventFromHydrateItem(h *plugin.HydrateData) (Event, error) {
+    if event, ok := h.Item.(Event); ok {
+        return event, nil
+    } else {
+        return Event{}, fmt.Errorf("unable to parse hydrate item %v as an Event", h.Item)
+    }
+}
+
+func eventHydrateId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Turbot.ID, nil
+}
+
+func eventHydrateSource(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Source, nil
+}
+
+func eventHydrateEventType(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Type, nil
+}
+
+func eventHydrateResourceId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Turbot.ResourceID, nil
+}
+
+func eventHydrateResourceTrunkTitle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Resource.Trunk.Title, nil
+}
+
+func eventHydrateResourceTypeUri(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Resource.Type.URI, nil
+}
+
+func eventHydrateProcessId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Turbot.ProcessID, nil
+}
+
+func eventHydrateCreateTimestamp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+    event, err := extractEventFromHydrateItem(h)
+    if err != nil {
+        return nil, err
+    }
+    return event.Turbot.CreateTimestamp, nil
+}
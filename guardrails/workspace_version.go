@@ -0,0 +1,131 @@
+package turbot
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/turbot/steampipe-plugin-guardrails/errors"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// workspaceFeature is a GraphQL field that older Turbot Guardrails workspaces
+// don't provide. Probe is a minimal query selecting the field, used to check
+// whether the workspace schema has it.
+type workspaceFeature struct {
+	Column string
+	Probe  string
+}
+
+const (
+	probeNotificationActiveGrantsVersionIds = `
+query probeNotificationActiveGrantsVersionIds {
+  notifications(filter: ["limit:1"]) {
+    items {
+      turbot {
+        activeGrantsNewVersionId
+        activeGrantsOldVersionId
+      }
+    }
+  }
+}
+`
+
+	probeIdentityProfileId = `
+query probeIdentityProfileId {
+  grants(filter: ["limit:1"]) {
+    items {
+      identity {
+        profileId: get(path: "profileId")
+      }
+    }
+  }
+}
+`
+)
+
+// workspaceFeatures are keyed by the include variable that selects the field in
+// the query. Older workspaces reject queries that select these fields, so they
+// are left out and the column is returned as null instead.
+var workspaceFeatures = map[string]workspaceFeature{
+	"includeNotificationTurbotActiveGrantsNewVersionId": {Column: "active_grant_new_version_id", Probe: probeNotificationActiveGrantsVersionIds},
+	"includeNotificationTurbotActiveGrantsOldVersionId": {Column: "active_grant_old_version_id", Probe: probeNotificationActiveGrantsVersionIds},
+	"includeNotificationActiveGrantIdentityProfileId":   {Column: "active_grant_identity_profile_id", Probe: probeIdentityProfileId},
+	"includeNotificationGrantIdentityProfileId":         {Column: "grant_identity_profile_id", Probe: probeIdentityProfileId},
+	"includeGrantIdentityProfileId":                     {Column: "identity_profile_id", Probe: probeIdentityProfileId},
+	"includeActiveGrantIdentityProfileId":               {Column: "identity_profile_id", Probe: probeIdentityProfileId},
+	"includeEffectivePermissionUserProfileId":           {Column: "user_profile_id", Probe: probeIdentityProfileId},
+	"includeFavoriteIdentityProfileId":                  {Column: "identity_profile_id", Probe: probeIdentityProfileId},
+}
+
+// workspaceSupportsFeature runs the probe query for the feature. Any probe
+// error means the feature is treated as unsupported, since older workspaces
+// fail in different ways, e.g. get(path:) is valid schema but may still
+// error. The result is cached for the connection, except after errors other
+// than an unknown field, which may be temporary.
+func workspaceSupportsFeature(ctx context.Context, d *plugin.QueryData, feature workspaceFeature) bool {
+	cacheKey := fmt.Sprintf("guardrails_workspace_feature_%x", sha256.Sum256([]byte(feature.Probe)))
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(bool)
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Warn("workspaceSupportsFeature", "column", feature.Column, "connection_error", err)
+		return false
+	}
+	err = conn.DoRequest(feature.Probe, nil, &map[string]interface{}{})
+	if err != nil {
+		plugin.Logger(ctx).Warn("workspaceSupportsFeature", "column", feature.Column, "probe_error", err)
+		if errors.UnknownFieldError(err) {
+			d.ConnectionManager.Cache.Set(cacheKey, false)
+		}
+		return false
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, true)
+	return true
+}
+
+// getWorkspaceVersion returns the version of the Turbot Guardrails workspace.
+// The version is looked up once and cached for the connection.
+func getWorkspaceVersion(ctx context.Context, d *plugin.QueryData) (*semver.Version, error) {
+	cacheKey := "guardrails_workspace_version"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*semver.Version), nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	version, err := conn.GetTurbotWorkspaceVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, version)
+	return version, nil
+}
+
+// excludeUnsupportedIncludes turns off includes for fields the workspace
+// doesn't support, or that can't be probed, so the query succeeds and the
+// columns are null.
+func excludeUnsupportedIncludes(ctx context.Context, d *plugin.QueryData, m *map[string]interface{}) {
+	for include, feature := range workspaceFeatures {
+		if enabled, ok := (*m)[include].(bool); !ok || !enabled {
+			continue
+		}
+
+		if !workspaceSupportsFeature(ctx, d, feature) {
+			// The version is only logged, to help match the column to a release
+			version := "unknown"
+			if v, err := getWorkspaceVersion(ctx, d); err == nil {
+				version = v.String()
+			}
+			plugin.Logger(ctx).Warn("excludeUnsupportedIncludes", "table", d.Table.Name, "column", feature.Column, "workspace_version", version, "message", "column is not supported by the workspace and will be null")
+			(*m)[include] = false
+		}
+	}
+}